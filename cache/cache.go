@@ -0,0 +1,156 @@
+// Package cache keeps a local copy of the budget data that rarely changes between runs,
+// so that each run requests only what has changed since the last run from YNAB.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// Collection is a cached copy of one kind of entity in a budget, as of the server knowledge at which it was last refreshed.
+type Collection[T any] struct {
+	ServerKnowledge int `json:"server_knowledge"`
+	Items           []T `json:"items"`
+}
+
+// Apply merges the given changes, returned by a delta request, into the collection:
+// changed entities replace the cached entity with the same ID, new entities are added, and deleted entities are removed.
+func (c *Collection[T]) Apply(changes []cliynab.Changed[T], serverKnowledge int, idOf func(T) string) {
+	indexesByID := make(map[string]int, len(c.Items))
+	for itemIndex, item := range c.Items {
+		indexesByID[idOf(item)] = itemIndex
+	}
+
+	deletedIDs := make(map[string]bool)
+	for _, change := range changes {
+		id := idOf(change.Entity)
+		if change.Deleted {
+			deletedIDs[id] = true
+			continue
+		}
+
+		delete(deletedIDs, id)
+		if itemIndex, isCached := indexesByID[id]; isCached {
+			c.Items[itemIndex] = change.Entity
+		} else {
+			indexesByID[id] = len(c.Items)
+			c.Items = append(c.Items, change.Entity)
+		}
+	}
+
+	if len(deletedIDs) > 0 {
+		remaining := make([]T, 0, len(c.Items))
+		for _, item := range c.Items {
+			if !deletedIDs[idOf(item)] {
+				remaining = append(remaining, item)
+			}
+		}
+		c.Items = remaining
+	}
+
+	c.ServerKnowledge = serverKnowledge
+}
+
+// Budget is the cached data of a single budget.
+type Budget struct {
+	Accounts              Collection[cliynab.Account]                    `json:"accounts"`
+	Payees                Collection[cliynab.Payee]                      `json:"payees"`
+	ScheduledTransactions Collection[cliynab.ScheduledTransactionDetail] `json:"scheduled_transactions"`
+}
+
+// Refresh brings the cached data up to date with YNAB, requesting only the changes since the data was last refreshed.
+func (b *Budget) Refresh(ctx context.Context, client cliynab.API, budgetID string) error {
+	accountChanges, accountKnowledge, err := client.ListAccountsSince(ctx, budgetID, b.Accounts.ServerKnowledge)
+	if err != nil {
+		return fmt.Errorf("failed to refresh accounts: %w", err)
+	}
+	b.Accounts.Apply(accountChanges, accountKnowledge, func(account cliynab.Account) string { return account.Id })
+
+	payeeChanges, payeeKnowledge, err := client.ListPayeesSince(ctx, budgetID, b.Payees.ServerKnowledge)
+	if err != nil {
+		return fmt.Errorf("failed to refresh payees: %w", err)
+	}
+	b.Payees.Apply(payeeChanges, payeeKnowledge, func(payee cliynab.Payee) string { return payee.Id })
+
+	scheduledChanges, scheduledKnowledge, err := client.ListScheduledTransactionsSince(ctx, budgetID, b.ScheduledTransactions.ServerKnowledge)
+	if err != nil {
+		return fmt.Errorf("failed to refresh scheduled transactions: %w", err)
+	}
+	b.ScheduledTransactions.Apply(scheduledChanges, scheduledKnowledge, func(transaction cliynab.ScheduledTransactionDetail) string { return transaction.Id })
+
+	return nil
+}
+
+// Cache is the cached data of each budget, keyed by the budget's ID.
+type Cache struct {
+	Budgets map[string]*Budget `json:"budgets"`
+}
+
+// Budget returns the cached data of the given budget, which is empty if the budget has not been cached.
+func (c *Cache) Budget(budgetID string) *Budget {
+	if c.Budgets == nil {
+		c.Budgets = make(map[string]*Budget)
+	}
+
+	budget, isCached := c.Budgets[budgetID]
+	if !isCached {
+		budget = &Budget{}
+		c.Budgets[budgetID] = budget
+	}
+
+	return budget
+}
+
+// Load reads the cache from the given file. If the file does not exist, then an empty cache is returned.
+func Load(path string) (*Cache, error) {
+	fileBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Cache{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read cache file '%s': %w", path, err)
+	}
+
+	var cache Cache
+	if err := json.Unmarshal(fileBytes, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse cache file '%s': %w", path, err)
+	}
+
+	return &cache, nil
+}
+
+// Save writes the cache to the given file. The cache is written to a temporary file that then replaces the given file,
+// so that an interrupted write does not leave behind a cache that cannot be read.
+func (c *Cache) Save(path string) error {
+	cacheBytes, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary cache file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(cacheBytes); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("failed to write temporary cache file '%s': %w", tempFile.Name(), err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary cache file '%s': %w", tempFile.Name(), err)
+	}
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace cache file '%s': %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package cache_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/cache"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Cache", func() {
+	accountID := func(account cliynab.Account) string { return account.Id }
+
+	Context("Collection", func() {
+		Context("Apply", func() {
+			It("replaces changed entities, adds new entities, and removes deleted entities", func() {
+				collection := cache.Collection[cliynab.Account]{
+					ServerKnowledge: 10,
+					Items: []cliynab.Account{
+						{Id: "account-0", Balance: 100},
+						{Id: "account-1", Balance: 200},
+						{Id: "account-2", Balance: 300},
+					},
+				}
+
+				collection.Apply([]cliynab.Changed[cliynab.Account]{
+					{Entity: cliynab.Account{Id: "account-1", Balance: 250}},
+					{Entity: cliynab.Account{Id: "account-2"}, Deleted: true},
+					{Entity: cliynab.Account{Id: "account-3", Balance: 400}},
+				}, 12, accountID)
+
+				Expect(collection.ServerKnowledge).To(Equal(12), "the server knowledge should be updated")
+				Expect(collection.Items).To(Equal([]cliynab.Account{
+					{Id: "account-0", Balance: 100},
+					{Id: "account-1", Balance: 250},
+					{Id: "account-3", Balance: 400},
+				}), "the changes should be merged into the cached entities")
+			})
+
+			It("ignores the deletion of an entity that was never cached", func() {
+				collection := cache.Collection[cliynab.Account]{}
+
+				collection.Apply([]cliynab.Changed[cliynab.Account]{
+					{Entity: cliynab.Account{Id: "account-0"}, Deleted: true},
+				}, 3, accountID)
+
+				Expect(collection.Items).To(BeEmpty(), "there should be no cached entities")
+				Expect(collection.ServerKnowledge).To(Equal(3), "the server knowledge should be updated")
+			})
+		})
+	})
+
+	Context("Budget", func() {
+		Context("Refresh", func() {
+			var requests []*http.Request
+			var client *cliynab.Client
+
+			BeforeEach(func() {
+				requests = nil
+
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests = append(requests, r)
+					w.Header().Set("Content-Type", "application/json")
+
+					switch r.URL.Path {
+					case "/v1/budgets/budget-0/accounts":
+						_, _ = w.Write([]byte(`{"data":{"accounts":[{"id":"account-1","balance":250},{"id":"account-0","deleted":true}],"server_knowledge":21}}`))
+					case "/v1/budgets/budget-0/payees":
+						_, _ = w.Write([]byte(`{"data":{"payees":[{"id":"payee-0","name":"Electric Co"}],"server_knowledge":21}}`))
+					case "/v1/budgets/budget-0/scheduled_transactions":
+						_, _ = w.Write([]byte(`{"data":{"scheduled_transactions":[],"server_knowledge":21}}`))
+					default:
+						w.WriteHeader(http.StatusNotFound)
+					}
+				}))
+				DeferCleanup(server.Close)
+
+				baseURL, err := url.Parse(server.URL + "/v1/")
+				Expect(err).ToNot(HaveOccurred(), "parsing the test server URL should not fail")
+
+				client = cliynab.NewClient(baseURL, server.Client(), "access-token")
+			})
+
+			It("requests only the changes since each collection was last refreshed", func() {
+				budget := &cache.Budget{
+					Accounts: cache.Collection[cliynab.Account]{
+						ServerKnowledge: 20,
+						Items:           []cliynab.Account{{Id: "account-0"}, {Id: "account-1", Balance: 100}},
+					},
+				}
+
+				Expect(budget.Refresh(context.Background(), client, "budget-0")).To(Succeed(), "refreshing the budget should succeed")
+
+				Expect(requests).To(HaveLen(3), "each collection should be requested once")
+				Expect(requests[0].URL.Query().Get("last_knowledge_of_server")).To(Equal("20"), "the accounts should be requested since their server knowledge")
+				Expect(requests[1].URL.Query().Has("last_knowledge_of_server")).To(BeFalse(), "the payees have never been cached, so all of them should be requested")
+
+				Expect(budget.Accounts.Items).To(Equal([]cliynab.Account{{Id: "account-1", Balance: 250}}), "the account changes should be applied")
+				Expect(budget.Accounts.ServerKnowledge).To(Equal(21), "the server knowledge of the accounts should be updated")
+				Expect(budget.Payees.Items).To(HaveLen(1), "the payees should be cached")
+				Expect(budget.ScheduledTransactions.ServerKnowledge).To(Equal(21), "the server knowledge of the scheduled transactions should be updated")
+			})
+		})
+	})
+
+	Context("Load", func() {
+		It("returns an empty cache if the file does not exist", func() {
+			budgetCache, err := cache.Load(filepath.Join(GinkgoT().TempDir(), "missing.json"))
+			Expect(err).ToNot(HaveOccurred(), "loading a missing cache file should not fail")
+
+			budget := budgetCache.Budget("budget-0")
+			Expect(budget.Accounts.ServerKnowledge).To(BeZero(), "an uncached budget should have no server knowledge")
+			Expect(budget.Accounts.Items).To(BeEmpty(), "an uncached budget should have no accounts")
+		})
+
+		It("fails if the file cannot be parsed", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "cache.json")
+			Expect(os.WriteFile(path, []byte("not json"), 0o600)).To(Succeed(), "writing the cache file should succeed")
+
+			_, err := cache.Load(path)
+			Expect(err).To(HaveOccurred(), "an unparseable cache file should fail to load")
+		})
+	})
+
+	Context("Save", func() {
+		It("writes a cache that can be loaded", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "cache.json")
+
+			budgetCache := &cache.Cache{}
+			budget := budgetCache.Budget("budget-0")
+			budget.Accounts.Apply([]cliynab.Changed[cliynab.Account]{{Entity: cliynab.Account{Id: "account-0", Balance: 100}}}, 5, accountID)
+			Expect(budgetCache.Save(path)).To(Succeed(), "saving the cache should succeed")
+
+			loaded, err := cache.Load(path)
+			Expect(err).ToNot(HaveOccurred(), "loading the saved cache should not fail")
+			Expect(loaded.Budget("budget-0").Accounts).To(Equal(budget.Accounts), "the cached accounts should round-trip")
+			Expect(loaded.Budget("budget-1").Accounts.Items).To(BeEmpty(), "other budgets should not be cached")
+		})
+	})
+})
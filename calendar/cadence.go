@@ -0,0 +1,68 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// Cadence determines the window of dates that is funded by default.
+type Cadence interface {
+	// NextWindow returns the start and end dates (inclusive) of the first window that starts after the given date.
+	NextWindow(today civil.Date) (civil.Date, civil.Date)
+}
+
+// WeeklyCadence funds whole weeks, such as Monday through Sunday.
+type WeeklyCadence struct {
+	WeekStart time.Weekday // the first day of each week
+	Weeks     int          // the number of weeks in each window
+}
+
+var _ Cadence = (*WeeklyCadence)(nil)
+
+// NextWindow returns the given number of weeks, starting on the first week start after the given date.
+func (w *WeeklyCadence) NextWindow(today civil.Date) (civil.Date, civil.Date) {
+	start := today.AddDays(1)
+	for start.Weekday() != w.WeekStart {
+		start = start.AddDays(1)
+	}
+
+	return start, start.AddDays(7*w.Weeks - 1)
+}
+
+// PayPeriodCadence funds whole pay periods of a fixed length, such as every other Friday.
+type PayPeriodCadence struct {
+	Anchor     civil.Date // any date on which a pay period starts
+	PeriodDays int        // the number of days in each pay period
+	Periods    int        // the number of pay periods in each window
+}
+
+var _ Cadence = (*PayPeriodCadence)(nil)
+
+// NextWindow returns the given number of pay periods, starting on the first pay period start after the given date.
+func (p *PayPeriodCadence) NextWindow(today civil.Date) (civil.Date, civil.Date) {
+	daysSinceAnchor := today.DaysSince(p.Anchor)
+
+	// floor the number of elapsed periods so that anchors after today are handled, too
+	elapsedPeriods := daysSinceAnchor / p.PeriodDays
+	if daysSinceAnchor < 0 && daysSinceAnchor%p.PeriodDays != 0 {
+		elapsedPeriods--
+	}
+
+	start := p.Anchor.AddDays((elapsedPeriods + 1) * p.PeriodDays)
+
+	return start, start.AddDays(p.Periods*p.PeriodDays - 1)
+}
+
+// ParseWeekday returns the day of the week with the given name, such as "monday", without regard to case.
+func ParseWeekday(name string) (time.Weekday, error) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), strings.TrimSpace(name)) {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, fmt.Errorf("unknown day of the week '%s'", name)
+}
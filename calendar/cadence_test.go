@@ -0,0 +1,58 @@
+package calendar_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+var _ = Describe("Cadence", func() {
+	// 2025-07-02 is a Wednesday
+	today := civil.Date{Year: 2025, Month: time.July, Day: 2}
+
+	formatWindow := func(start, end civil.Date) []string {
+		return []string{start.String(), end.String()}
+	}
+
+	Context("WeeklyCadence", func() {
+		It("returns the next whole weeks", func() {
+			cadence := &calendar.WeeklyCadence{WeekStart: time.Monday, Weeks: 1}
+			Expect(formatWindow(cadence.NextWindow(today))).To(Equal([]string{"2025-07-07", "2025-07-13"}), "the window should run from next Monday through Sunday")
+		})
+
+		It("starts after today even if today starts a week", func() {
+			cadence := &calendar.WeeklyCadence{WeekStart: time.Wednesday, Weeks: 2}
+			Expect(formatWindow(cadence.NextWindow(today))).To(Equal([]string{"2025-07-09", "2025-07-22"}), "the window should start a week from today")
+		})
+	})
+
+	Context("PayPeriodCadence", func() {
+		DescribeTable("the next pay periods",
+			func(anchor string, periods int, expected []string) {
+				anchorDate, err := civil.ParseDate(anchor)
+				Expect(err).ToNot(HaveOccurred(), "the anchor should parse")
+
+				cadence := &calendar.PayPeriodCadence{Anchor: anchorDate, PeriodDays: 14, Periods: periods}
+				Expect(formatWindow(cadence.NextWindow(today))).To(Equal(expected), "the window should cover the next pay periods")
+			},
+			Entry("anchored in the past", "2025-01-03", 1, []string{"2025-07-04", "2025-07-17"}),
+			Entry("anchored in the future", "2025-12-26", 2, []string{"2025-07-11", "2025-08-07"}),
+			Entry("anchored today", "2025-07-02", 1, []string{"2025-07-16", "2025-07-29"}),
+		)
+	})
+
+	Context("ParseWeekday", func() {
+		It("parses the day without regard to case", func() {
+			Expect(calendar.ParseWeekday("MONDAY")).To(Equal(time.Monday), "the day should be parsed")
+		})
+
+		It("rejects unknown days", func() {
+			_, err := calendar.ParseWeekday("mondy")
+			Expect(err).To(HaveOccurred(), "an unknown day should be rejected")
+		})
+	})
+})
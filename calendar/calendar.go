@@ -0,0 +1,59 @@
+// Package calendar determines business days, which are used to estimate when sent funds will arrive,
+// and the default windows of dates to be funded.
+package calendar
+
+import (
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// Calendar determines which days are business days: weekdays that are not holidays.
+type Calendar struct {
+	holidays map[civil.Date]bool
+}
+
+// New creates a Calendar that observes the given holidays.
+func New(holidays []civil.Date) *Calendar {
+	holidaySet := make(map[civil.Date]bool, len(holidays))
+	for _, holiday := range holidays {
+		holidaySet[holiday] = true
+	}
+
+	return &Calendar{
+		holidays: holidaySet,
+	}
+}
+
+// IsBusinessDay returns true if the given date is neither a weekend nor a holiday.
+func (c *Calendar) IsBusinessDay(date civil.Date) bool {
+	if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+		return false
+	}
+
+	return !c.holidays[date]
+}
+
+// AddBusinessDays returns the date that is the given number of business days after the given date.
+// If no business days are to be added, the given date is returned as-is.
+func (c *Calendar) AddBusinessDays(date civil.Date, businessDays int) civil.Date {
+	day := date
+	for added := 0; added < businessDays; {
+		day = day.AddDays(1)
+		if c.IsBusinessDay(day) {
+			added++
+		}
+	}
+
+	return day
+}
+
+// PreviousBusinessDay returns the given date if it is a business day; otherwise, the closest business day before it is returned.
+func (c *Calendar) PreviousBusinessDay(date civil.Date) civil.Date {
+	day := date
+	for !c.IsBusinessDay(day) {
+		day = day.AddDays(-1)
+	}
+
+	return day
+}
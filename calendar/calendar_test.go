@@ -0,0 +1,108 @@
+package calendar_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+var _ = Describe("Calendar", func() {
+	date := func(value string) civil.Date {
+		parsed, err := civil.ParseDate(value)
+		Expect(err).ToNot(HaveOccurred(), "the test date should parse")
+
+		return parsed
+	}
+
+	Context("AddBusinessDays", func() {
+		// 2025-07-04 is a Friday
+		var businessCalendar *calendar.Calendar
+
+		BeforeEach(func() {
+			businessCalendar = calendar.New([]civil.Date{date("2025-07-04")})
+		})
+
+		DescribeTable("adding business days",
+			func(from string, businessDays int, expected string) {
+				Expect(businessCalendar.AddBusinessDays(date(from), businessDays).String()).To(Equal(expected), "the expected business day should be returned")
+			},
+			Entry("no business days", "2025-07-05", 0, "2025-07-05"),
+			Entry("within a week", "2025-07-01", 2, "2025-07-03"),
+			Entry("over a holiday and weekend", "2025-07-03", 1, "2025-07-07"),
+			Entry("from a weekend", "2025-07-05", 3, "2025-07-09"),
+		)
+
+	})
+
+	Context("PreviousBusinessDay", func() {
+		It("moves weekends and holidays back to the prior business day", func() {
+			businessCalendar := calendar.New([]civil.Date{date("2025-07-04")})
+
+			Expect(businessCalendar.PreviousBusinessDay(date("2025-07-03"))).To(Equal(date("2025-07-03")), "a business day should not be moved")
+			Expect(businessCalendar.PreviousBusinessDay(date("2025-07-06"))).To(Equal(date("2025-07-03")), "a Sunday after a Friday holiday should move to Thursday")
+		})
+	})
+
+	Context("LoadHolidays", func() {
+		var holidayFile string
+
+		BeforeEach(func() {
+			holidayFile = filepath.Join(GinkgoT().TempDir(), "holidays.txt")
+		})
+
+		It("reads one holiday per line", func() {
+			Expect(os.WriteFile(holidayFile, []byte("# US bank holidays\n2025-11-27\n\n2025-12-25\n"), 0o600)).To(Succeed(), "writing the holiday file should succeed")
+
+			businessCalendar, err := calendar.LoadHolidays(holidayFile)
+			Expect(err).ToNot(HaveOccurred(), "loading the holidays should not fail")
+			Expect(businessCalendar.IsBusinessDay(date("2025-11-27"))).To(BeFalse(), "Thanksgiving should be a holiday")
+			Expect(businessCalendar.IsBusinessDay(date("2025-12-25"))).To(BeFalse(), "Christmas should be a holiday")
+			Expect(businessCalendar.IsBusinessDay(date("2025-12-26"))).To(BeTrue(), "the day after Christmas should be a business day")
+		})
+
+		It("reads the events of an iCalendar file", func() {
+			holidayFile = filepath.Join(GinkgoT().TempDir(), "holidays.ics")
+			document := "BEGIN:VCALENDAR\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART;VALUE=DATE:20251127\r\n" +
+				"SUMMARY:Thanksgiving\r\n" +
+				"  Day\r\n" +
+				"END:VEVENT\r\n" +
+				"BEGIN:VEVENT\r\n" +
+				"DTSTART:20251225T000000Z\r\n" +
+				"SUMMARY:Christmas Day\r\n" +
+				"END:VEVENT\r\n" +
+				"END:VCALENDAR\r\n"
+			Expect(os.WriteFile(holidayFile, []byte(document), 0o600)).To(Succeed(), "writing the holiday file should succeed")
+
+			businessCalendar, err := calendar.LoadHolidays(holidayFile)
+			Expect(err).ToNot(HaveOccurred(), "loading the holidays should not fail")
+			Expect(businessCalendar.IsBusinessDay(date("2025-11-27"))).To(BeFalse(), "the all-day event should be a holiday")
+			Expect(businessCalendar.IsBusinessDay(date("2025-12-25"))).To(BeFalse(), "the timed event should be a holiday")
+			Expect(businessCalendar.IsBusinessDay(date("2025-11-28"))).To(BeTrue(), "the day after Thanksgiving should be a business day")
+		})
+
+		It("reads a YAML list of holidays", func() {
+			holidayFile = filepath.Join(GinkgoT().TempDir(), "holidays.yaml")
+			document := "holidays:\n  - 2025-11-27\n  - date: 2025-12-25\n    name: Christmas Day\n"
+			Expect(os.WriteFile(holidayFile, []byte(document), 0o600)).To(Succeed(), "writing the holiday file should succeed")
+
+			businessCalendar, err := calendar.LoadHolidays(holidayFile)
+			Expect(err).ToNot(HaveOccurred(), "loading the holidays should not fail")
+			Expect(businessCalendar.IsBusinessDay(date("2025-11-27"))).To(BeFalse(), "the plain date should be a holiday")
+			Expect(businessCalendar.IsBusinessDay(date("2025-12-25"))).To(BeFalse(), "the named holiday should be a holiday")
+		})
+
+		It("reports the line of a malformed holiday", func() {
+			Expect(os.WriteFile(holidayFile, []byte("2025-11-27\n12/25/2025\n"), 0o600)).To(Succeed(), "writing the holiday file should succeed")
+
+			_, err := calendar.LoadHolidays(holidayFile)
+			Expect(err).To(MatchError(ContainSubstring("line 2")), "the malformed line should be reported")
+		})
+	})
+})
@@ -0,0 +1,166 @@
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// LoadHolidays reads a Calendar from the holiday file at the given path. The format of the file is determined by its extension:
+//   - .ics files are iCalendar files; the start date of each event is a holiday. Recurrence rules are not expanded.
+//   - .yaml and .yml files hold a list of holidays under a holidays key, each either a YYYY-MM-DD date or a mapping with a date and an optional name.
+//   - any other file lists one holiday per line as a YYYY-MM-DD date; blank lines and lines starting with # are ignored.
+func LoadHolidays(path string) (*Calendar, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read holiday file '%s': %w", path, err)
+	}
+
+	var holidays []civil.Date
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics":
+		holidays, err = parseICSHolidays(fileBytes)
+	case ".yaml", ".yml":
+		holidays, err = parseYAMLHolidays(fileBytes)
+	default:
+		holidays, err = parseTextHolidays(fileBytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse holiday file '%s': %w", path, err)
+	}
+
+	return New(holidays), nil
+}
+
+func parseTextHolidays(fileBytes []byte) ([]civil.Date, error) {
+	var holidays []civil.Date
+	scanner := bufio.NewScanner(bytes.NewReader(fileBytes))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		holiday, err := civil.ParseDate(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse holiday on line %d: %w", lineNumber, err)
+		}
+
+		holidays = append(holidays, holiday)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read holidays: %w", err)
+	}
+
+	return holidays, nil
+}
+
+// parseICSHolidays reads the DTSTART of each VEVENT within the given iCalendar document.
+// Both all-day (20251225) and date-time (20251225T000000Z) values are accepted; only the date is used.
+func parseICSHolidays(fileBytes []byte) ([]civil.Date, error) {
+	var holidays []civil.Date
+	inEvent := false
+	for _, line := range unfoldICSLines(fileBytes) {
+		name, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+
+		// property parameters, such as VALUE=DATE, follow the property name after a semicolon
+		name, _, _ = strings.Cut(name, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			inEvent = inEvent || strings.EqualFold(value, "VEVENT")
+		case "END":
+			inEvent = inEvent && !strings.EqualFold(value, "VEVENT")
+		case "DTSTART":
+			if !inEvent {
+				continue
+			}
+
+			if len(value) < len("20060102") {
+				return nil, fmt.Errorf("malformed event start '%s'", value)
+			}
+
+			holiday, err := time.Parse("20060102", value[:len("20060102")])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse event start '%s': %w", value, err)
+			}
+
+			holidays = append(holidays, civil.DateOf(holiday))
+		}
+	}
+
+	if inEvent {
+		return nil, errors.New("the last event is not terminated by END:VEVENT")
+	}
+
+	return holidays, nil
+}
+
+// unfoldICSLines splits the given iCalendar document into its content lines,
+// joining lines that were folded onto continuation lines beginning with a space or tab.
+func unfoldICSLines(fileBytes []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(string(fileBytes), "\r\n", "\n"), "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	return lines
+}
+
+type yamlHolidays struct {
+	Holidays []yamlHoliday `yaml:"holidays"`
+}
+
+type yamlHoliday struct {
+	Date string `yaml:"date"`
+	Name string `yaml:"name"`
+}
+
+// UnmarshalYAML allows a holiday to be given as just its date.
+func (y *yamlHoliday) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		y.Date = node.Value
+		return nil
+	}
+
+	type plainHoliday yamlHoliday
+
+	return node.Decode((*plainHoliday)(y))
+}
+
+func parseYAMLHolidays(fileBytes []byte) ([]civil.Date, error) {
+	var document yamlHolidays
+	if err := yaml.Unmarshal(fileBytes, &document); err != nil {
+		return nil, fmt.Errorf("failed to decode holidays: %w", err)
+	}
+
+	holidays := make([]civil.Date, len(document.Holidays))
+	for holidayIndex, yamlHoliday := range document.Holidays {
+		holiday, err := civil.ParseDate(yamlHoliday.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of holiday %d ('%s'): %w", holidayIndex+1, yamlHoliday.Name, err)
+		}
+
+		holidays[holidayIndex] = holiday
+	}
+
+	return holidays, nil
+}
@@ -0,0 +1,14 @@
+package civil_test
+
+import (
+	"testing"
+	_ "time/tzdata" // the tests load named time zones, which may not be installed where they are run
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCivil(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Civil Suite")
+}
@@ -0,0 +1,39 @@
+package civil
+
+import (
+	"time"
+)
+
+// Clock provides the current time. It allows the current date to be controlled,
+// such as in tests, rather than read directly from the system.
+type Clock interface {
+	// Now returns the current time, in the location whose date is to be treated as today.
+	Now() time.Time
+}
+
+// SystemClock reads the current time from the system, in the local time zone.
+type SystemClock struct{}
+
+var _ Clock = SystemClock{}
+
+// Now returns the system's current time in the local time zone.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// FixedClock always returns the same time.
+type FixedClock struct {
+	Time time.Time // the time to be returned, in the location whose date is to be treated as today
+}
+
+var _ Clock = FixedClock{}
+
+// Now returns the clock's fixed time.
+func (f FixedClock) Now() time.Time {
+	return f.Time
+}
+
+// Today returns the current date of the given clock, in the location of the clock's current time.
+func Today(clock Clock) Date {
+	return DateOf(clock.Now())
+}
@@ -0,0 +1,43 @@
+package civil_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+var _ = Describe("Clock", func() {
+	Context("Today", func() {
+		It("returns the date in the location of the clock's time", func() {
+			centralTime := time.FixedZone("CST", -6*60*60)
+			clock := civil.FixedClock{Time: time.Date(2025, time.November, 2, 23, 30, 0, 0, centralTime)}
+
+			Expect(civil.Today(clock)).To(Equal(civil.Date{Year: 2025, Month: time.November, Day: 2}), "today should be the local date even though it is already tomorrow in UTC")
+			Expect(civil.Today(clock).AddDays(-1)).To(Equal(civil.Date{Year: 2025, Month: time.November, Day: 1}), "yesterday should be the local date before today")
+		})
+
+		It("changes date at midnight in the location of the clock's time", func() {
+			chicago, err := time.LoadLocation("America/Chicago")
+			Expect(err).ToNot(HaveOccurred(), "loading the Central time zone should not fail")
+
+			beforeMidnight := civil.FixedClock{Time: time.Date(2025, time.March, 8, 23, 59, 59, 0, chicago)}
+			Expect(civil.Today(beforeMidnight)).To(Equal(civil.Date{Year: 2025, Month: time.March, Day: 8}), "today should be the local date until midnight")
+			Expect(beforeMidnight.Now().UTC().Day()).To(Equal(9), "it should already be the next day in UTC")
+
+			atMidnight := civil.FixedClock{Time: beforeMidnight.Time.Add(time.Second)}
+			Expect(civil.Today(atMidnight)).To(Equal(civil.Date{Year: 2025, Month: time.March, Day: 9}), "today should be the next local date from midnight")
+		})
+
+		It("returns the date in a location ahead of UTC", func() {
+			tokyo, err := time.LoadLocation("Asia/Tokyo")
+			Expect(err).ToNot(HaveOccurred(), "loading the Japan time zone should not fail")
+
+			clock := civil.FixedClock{Time: time.Date(2025, time.January, 1, 0, 30, 0, 0, tokyo)}
+			Expect(civil.Today(clock)).To(Equal(civil.Date{Year: 2025, Month: time.January, Day: 1}), "today should be the local date even though it is still yesterday in UTC")
+			Expect(clock.Now().UTC().Year()).To(Equal(2024), "it should still be the previous year in UTC")
+		})
+	})
+})
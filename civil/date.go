@@ -0,0 +1,101 @@
+// Package civil represents calendar dates without a time of day or time zone,
+// so that a date such as a bill's due date means the same day wherever this tool is run.
+package civil
+
+import (
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date, such as 2025-07-04, without a time of day or time zone.
+// The zero Date is not a valid date; it is used to represent the absence of a date.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date on which the given time falls in the time's own location.
+func DateOf(dateTime time.Time) Date {
+	year, month, day := dateTime.Date()
+
+	return Date{
+		Year:  year,
+		Month: month,
+		Day:   day,
+	}
+}
+
+// ParseDate parses a date given as YYYY-MM-DD.
+func ParseDate(value string) (Date, error) {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return Date{}, fmt.Errorf("failed to parse date '%s': %w", value, err)
+	}
+
+	return DateOf(parsed), nil
+}
+
+// String returns the date as YYYY-MM-DD.
+func (d Date) String() string {
+	return d.Format(time.DateOnly)
+}
+
+// Format returns the date formatted according to the given layout, as accepted by time.Time.Format.
+// Any time-of-day elements of the layout are formatted as midnight UTC.
+func (d Date) Format(layout string) string {
+	return d.In(time.UTC).Format(layout)
+}
+
+// In returns the time at which the date starts (midnight) in the given location.
+func (d Date) In(location *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, location)
+}
+
+// IsZero returns true if this is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// AddDays returns the date that is the given number of days after this date; negative numbers of days move backwards.
+func (d Date) AddDays(days int) Date {
+	return DateOf(d.In(time.UTC).AddDate(0, 0, days))
+}
+
+// DaysSince returns the number of days from the given date to this date, which is negative if the given date is after this date.
+func (d Date) DaysSince(other Date) int {
+	// UTC has no daylight saving time, so every day is exactly 24 hours long
+	return int(d.In(time.UTC).Sub(other.In(time.UTC)) / (24 * time.Hour))
+}
+
+// Before returns true if this date is before the given date.
+func (d Date) Before(other Date) bool {
+	return d.DaysSince(other) < 0
+}
+
+// After returns true if this date is after the given date.
+func (d Date) After(other Date) bool {
+	return d.DaysSince(other) > 0
+}
+
+// Weekday returns the day of the week on which the date falls.
+func (d Date) Weekday() time.Weekday {
+	return d.In(time.UTC).Weekday()
+}
+
+// MarshalText returns the date as YYYY-MM-DD.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a date given as YYYY-MM-DD.
+func (d *Date) UnmarshalText(text []byte) error {
+	parsed, err := ParseDate(string(text))
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
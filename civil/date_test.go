@@ -0,0 +1,95 @@
+package civil_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+var _ = Describe("Date", func() {
+	Context("ParseDate", func() {
+		It("parses a date given as YYYY-MM-DD", func() {
+			date, err := civil.ParseDate("2025-07-04")
+			Expect(err).ToNot(HaveOccurred(), "parsing the date should not fail")
+			Expect(date).To(Equal(civil.Date{Year: 2025, Month: time.July, Day: 4}), "the date should be parsed")
+			Expect(date.String()).To(Equal("2025-07-04"), "the date should be formatted back as it was given")
+		})
+
+		It("rejects a date that is not given as YYYY-MM-DD", func() {
+			_, err := civil.ParseDate("07/04/2025")
+			Expect(err).To(HaveOccurred(), "parsing the date should fail")
+		})
+	})
+
+	Context("DateOf", func() {
+		It("uses the date in the time's own location", func() {
+			centralTime := time.FixedZone("CDT", -5*60*60)
+			lateEvening := time.Date(2025, time.March, 12, 23, 30, 0, 0, centralTime)
+
+			Expect(civil.DateOf(lateEvening)).To(Equal(civil.Date{Year: 2025, Month: time.March, Day: 12}), "the local date should be used, not the date in UTC")
+		})
+	})
+
+	Context("AddDays", func() {
+		var chicago *time.Location
+
+		BeforeEach(func() {
+			var err error
+			chicago, err = time.LoadLocation("America/Chicago")
+			Expect(err).ToNot(HaveOccurred(), "loading the Central time zone should not fail")
+		})
+
+		It("moves by whole days across the start of daylight saving time", func() {
+			// clocks in Chicago skip from 02:00 to 03:00 on 2025-03-09, so that day is only 23 hours long
+			lateEvening := time.Date(2025, time.March, 8, 23, 30, 0, 0, chicago)
+			Expect(lateEvening.Add(24*time.Hour).Day()).To(Equal(10), "a day's worth of hours should overshoot the day of the change")
+
+			date := civil.DateOf(lateEvening)
+			Expect(date.AddDays(1)).To(Equal(civil.Date{Year: 2025, Month: time.March, Day: 9}), "the day of the change should follow")
+			Expect(date.AddDays(2)).To(Equal(civil.Date{Year: 2025, Month: time.March, Day: 10}), "the day after the change should follow")
+			Expect(date.AddDays(2).In(chicago).Sub(date.AddDays(1).In(chicago))).To(Equal(23*time.Hour), "the day of the change should be 23 hours long in Chicago")
+		})
+
+		It("moves by whole days across the end of daylight saving time", func() {
+			// clocks in Chicago repeat 01:00 to 02:00 on 2025-11-02, so that day is 25 hours long
+			midnight := time.Date(2025, time.November, 2, 0, 0, 0, 0, chicago)
+			Expect(midnight.Add(24*time.Hour).Day()).To(Equal(2), "a day's worth of hours should fall short of the day after the change")
+
+			date := civil.DateOf(midnight)
+			Expect(date.AddDays(1)).To(Equal(civil.Date{Year: 2025, Month: time.November, Day: 3}), "the day after the change should follow")
+			Expect(date.AddDays(-1)).To(Equal(civil.Date{Year: 2025, Month: time.November, Day: 1}), "the day before the change should precede")
+			Expect(date.AddDays(-2)).To(Equal(civil.Date{Year: 2025, Month: time.October, Day: 31}), "negative days should move backwards across months")
+			Expect(date.AddDays(1).In(chicago).Sub(midnight)).To(Equal(25*time.Hour), "the day of the change should be 25 hours long in Chicago")
+		})
+	})
+
+	Context("DaysSince", func() {
+		It("counts the days between two dates", func() {
+			start := civil.Date{Year: 2025, Month: time.March, Day: 1}
+			end := civil.Date{Year: 2025, Month: time.March, Day: 31}
+
+			Expect(end.DaysSince(start)).To(Equal(30), "the days from the start to the end should be counted")
+			Expect(start.DaysSince(end)).To(Equal(-30), "the count should be negative when the given date is later")
+			Expect(start.Before(end)).To(BeTrue(), "the start should be before the end")
+			Expect(end.After(start)).To(BeTrue(), "the end should be after the start")
+			Expect(start.After(start)).To(BeFalse(), "a date should not be after itself")
+		})
+	})
+
+	Context("MarshalText", func() {
+		It("round-trips the date as YYYY-MM-DD", func() {
+			date := civil.Date{Year: 2025, Month: time.December, Day: 25}
+
+			text, err := date.MarshalText()
+			Expect(err).ToNot(HaveOccurred(), "marshalling the date should not fail")
+			Expect(string(text)).To(Equal("2025-12-25"), "the date should be marshalled as YYYY-MM-DD")
+
+			var unmarshalled civil.Date
+			Expect(unmarshalled.UnmarshalText(text)).To(Succeed(), "unmarshalling the date should not fail")
+			Expect(unmarshalled).To(Equal(date), "the date should be unchanged")
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/cache"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// loadBudgetData returns the budget's accounts, payees, and scheduled transactions, bringing the local cache of them up to date
+// by requesting only what has changed since the last run. The cache only spares requests to YNAB, so a cache that cannot be read
+// or written is logged and the data is requested in full instead.
+func loadBudgetData(ctx context.Context, apiClient cliynab.API, budgetID string, appConfig *config.Config) *cache.Budget {
+	logger := logging.FromContext(ctx)
+	cacheFile := resolveConfigRelativePath(appConfig.GetCacheFile())
+
+	budgetCache, err := cache.Load(cacheFile)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to read cache; requesting all budget data from YNAB", "file", cacheFile, "error", err)
+		budgetCache = &cache.Cache{}
+	}
+
+	if isRefreshCache() {
+		delete(budgetCache.Budgets, budgetID)
+	}
+
+	budgetData := budgetCache.Budget(budgetID)
+	if err := budgetData.Refresh(ctx, apiClient, budgetID); err != nil {
+		panic(fmt.Sprintf("Failed to get budget data: %v", err))
+	}
+
+	logger.DebugContext(ctx, "refreshed cached budget data",
+		"accounts", len(budgetData.Accounts.Items),
+		"payees", len(budgetData.Payees.Items),
+		"scheduled_transactions", len(budgetData.ScheduledTransactions.Items))
+
+	if err := budgetCache.Save(cacheFile); err != nil {
+		logger.WarnContext(ctx, "Failed to write cache; the next run will request these changes from YNAB again", "file", cacheFile, "error", err)
+	}
+
+	return budgetData
+}
+
+// isRefreshCache returns whether --refresh-cache was supplied, discarding the cached budget data so that all of it is requested from YNAB.
+func isRefreshCache() bool {
+	for _, arg := range os.Args {
+		if arg == "--refresh-cache" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// loadCalendar returns the business day calendar, observing the configured holidays if any.
+func loadCalendar(appConfig *config.Config) *calendar.Calendar {
+	if appConfig.Calendar == nil || appConfig.Calendar.Holidays == nil {
+		return calendar.New(nil)
+	}
+
+	businessCalendar, err := calendar.LoadHolidays(resolveConfigRelativePath(*appConfig.Calendar.Holidays))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load holiday calendar: %v", err))
+	}
+
+	return businessCalendar
+}
+
+// calculateArrivalDate returns the date on which funds sent today are expected to arrive in the offramp accounts,
+// according to the configured settlement delay and holiday calendar.
+func calculateArrivalDate(ctx context.Context, clock civil.Clock, appConfig *config.Config, businessCalendar *calendar.Calendar) civil.Date {
+	arrivalDate := businessCalendar.AddBusinessDays(civil.Today(clock), appConfig.GetSettlementDelayBusinessDays())
+
+	logging.FromContext(ctx).DebugContext(ctx, "calculated arrival date",
+		"settlement_delay_business_days", appConfig.GetSettlementDelayBusinessDays(),
+		"arrival_date", arrivalDate.String())
+
+	return arrivalDate
+}
+
+// getDefaultDateRange returns the window of dates offered by default: the next window of the configured cadence or,
+// if no cadence is configured, the seven days starting a week from today.
+func getDefaultDateRange(clock civil.Clock, appConfig *config.Config) (civil.Date, civil.Date) {
+	today := civil.Today(clock)
+
+	if appConfig.Calendar == nil || appConfig.Calendar.Window == nil {
+		startDate := today.AddDays(7)
+		return startDate, startDate.AddDays(6)
+	}
+
+	return buildCadence(appConfig.Calendar.Window).NextWindow(today)
+}
+
+// buildCadence converts the configured window into the cadence from which default windows are calculated.
+func buildCadence(window *config.WindowConfig) calendar.Cadence {
+	switch window.Cadence {
+	case config.WindowCadenceWeekly:
+		weekStart, err := calendar.ParseWeekday(window.GetWeekStart())
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse week start: %v", err))
+		}
+
+		return &calendar.WeeklyCadence{
+			WeekStart: weekStart,
+			Weeks:     window.GetPeriods(),
+		}
+	case config.WindowCadencePayPeriod:
+		payPeriodStart, _, err := window.PayPeriodStartDate()
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse pay period start: %v", err))
+		}
+
+		return &calendar.PayPeriodCadence{
+			Anchor:     payPeriodStart,
+			PeriodDays: window.GetPayPeriodDays(),
+			Periods:    window.GetPeriods(),
+		}
+	default:
+		panic(fmt.Sprintf("Unsupported window cadence: '%s'", window.Cadence))
+	}
+}
+
+// shiftBillsToBusinessDays moves bills scheduled on weekends and holidays to the business day before them, if configured to do so.
+// Bills that have already been entered in the offramp accounts are dropped rather than moved.
+func shiftBillsToBusinessDays(
+	ctx context.Context,
+	appConfig *config.Config,
+	businessCalendar *calendar.Calendar,
+	accountInfo accountInfoData,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+) []cliynab.ScheduledTransactionDetail {
+	if !appConfig.ShiftsBillsToBusinessDay() {
+		return scheduledTransactions
+	}
+
+	var enteredTransactions []cliynab.TransactionDetail
+	for _, accountTransactions := range accountInfo.transactionsByAccountID {
+		enteredTransactions = append(enteredTransactions, accountTransactions...)
+	}
+
+	shifted, err := math.ShiftBillsToPriorBusinessDays(businessCalendar, scheduledTransactions, enteredTransactions)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to shift bills to business days: %v", err))
+	}
+
+	scheduledDatesByID := make(map[string]string, len(scheduledTransactions))
+	for _, transaction := range scheduledTransactions {
+		scheduledDatesByID[transaction.Id] = transaction.DateNext
+	}
+
+	logger := logging.FromContext(ctx)
+	for _, transaction := range shifted {
+		if scheduledDate := scheduledDatesByID[transaction.Id]; transaction.DateNext != scheduledDate {
+			logger.DebugContext(ctx, "shifted bill to prior business day",
+				"payee", transaction.PayeeName,
+				"scheduled_date", scheduledDate,
+				"shifted_date", transaction.DateNext)
+		}
+	}
+
+	return shifted
+}
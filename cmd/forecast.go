@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+// runForecast prints, for each offramp account, a day-by-day projection of its balance from today through an end date.
+func runForecast(ctx context.Context, clock civil.Clock) {
+	outputFormat := getOutputFormat()
+	if outputFormat != outputFormatText && outputFormat != outputFormatJSON {
+		panic(fmt.Sprintf("Unsupported output format '%s'; must be one of '%s' or '%s'", outputFormat, outputFormatText, outputFormatJSON))
+	}
+
+	apiClient, budget, appConfig := setupYNABClient(ctx)
+
+	budgetData := loadBudgetData(ctx, apiClient, budget.Id, appConfig)
+
+	accountInfo := resolveAccountInfo(budgetData.Accounts.Items, appConfig)
+	// projections start from today, so only the future-dated transactions are needed
+	accountInfo.transactionsByAccountID = getAccountTransactions(ctx, apiClient, budget.Id, accountInfo, civil.Today(clock).AddDays(1))
+
+	endDate := getForecastEndDate(clock, appConfig)
+
+	scheduledTransactions := shiftBillsToBusinessDays(ctx, appConfig, loadCalendar(appConfig), accountInfo, budgetData.ScheduledTransactions.Items)
+
+	// Transfers between offramp accounts are scheduled in only one of them, so mirror them into the other
+	scheduledTransactions = math.MirrorInternalTransfers(accountInfo.offrampAccountIDs, scheduledTransactions)
+
+	forecasts := make([]*math.AccountForecast, 0, len(appConfig.YNABAccounts.OfframpAccounts))
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		accountID := resolveSingleAccountID(accountInfo.accountNamesByID, offrampAccount.Name, "offramp")
+
+		var minimumBalance *int
+		minimumBalanceCents, hasMinimumBalance, err := offrampAccount.MinimumBalanceAsCents()
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse minimum balance for account '%s': %v", offrampAccount.Name, err))
+		}
+		if hasMinimumBalance {
+			minimumBalance = &minimumBalanceCents
+		}
+
+		ynabAccount, accountTransactions := getProjectionAccount(ctx, clock, accountInfo, offrampAccount, accountID, scheduledTransactions)
+
+		forecast, err := math.ForecastAccount(clock, ynabAccount, accountTransactions, minimumBalance, endDate)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to forecast balance of account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+		}
+
+		forecasts = append(forecasts, forecast)
+	}
+
+	if outputFormat == outputFormatJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]any{
+			"end_date": endDate.String(),
+			"accounts": forecasts,
+		}); err != nil {
+			panic(fmt.Sprintf("Failed to write forecast as JSON: %v", err))
+		}
+
+		return
+	}
+
+	for _, forecast := range forecasts {
+		displayForecast(forecast)
+	}
+}
+
+func displayForecast(forecast *math.AccountForecast) {
+	fmt.Printf("%s\n", forecast.AccountName)
+	fmt.Printf("  Starting balance: %s\n", currency.FormatCents(forecast.StartingBalanceCents))
+	if forecast.MinimumBalanceCents != nil {
+		fmt.Printf("  Minimum balance: %s\n", currency.FormatCents(*forecast.MinimumBalanceCents))
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, day := range forecast.Days {
+		warning := ""
+		if day.BelowMinimum {
+			warning = "** below minimum **"
+		}
+
+		if len(day.Entries) == 0 {
+			fmt.Fprintf(writer, "  %s\t\t\t%s\t%s\n", day.Date, currency.FormatCents(day.BalanceCents), warning)
+			continue
+		}
+
+		for entryIndex, entry := range day.Entries {
+			// Only show the running balance once all of the day's transactions have been applied
+			balance := ""
+			dayWarning := ""
+			if entryIndex == len(day.Entries)-1 {
+				balance = currency.FormatCents(day.BalanceCents)
+				dayWarning = warning
+			}
+
+			fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n", day.Date, entry.Payee, currency.FormatCents(entry.AmountCents), balance, dayWarning)
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		panic(fmt.Sprintf("Failed to write forecast: %v", err))
+	}
+
+	if forecast.LowestBalanceDate == "" {
+		fmt.Printf("  Low-water mark: %s (the starting balance)\n\n", currency.FormatCents(forecast.LowestBalanceCents))
+	} else {
+		fmt.Printf("  Low-water mark: %s on %s\n\n", currency.FormatCents(forecast.LowestBalanceCents), forecast.LowestBalanceDate)
+	}
+}
+
+// getForecastEndDate returns the date supplied by --end-date or, if not supplied, prompts for it.
+func getForecastEndDate(clock civil.Clock, appConfig *config.Config) civil.Date {
+	for _, arg := range os.Args {
+		if strings.HasPrefix(arg, "--end-date=") {
+			endDateStr := strings.TrimPrefix(arg, "--end-date=")
+			endDate, err := civil.ParseDate(endDateStr)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to parse end date '%s': %v", endDateStr, err))
+			}
+
+			return endDate
+		}
+	}
+
+	// Default to the end of the default window used when calculating funding
+	_, defaultEndDate := getDefaultDateRange(clock, appConfig)
+
+	return promptForDate("End date", defaultEndDate)
+}
+
+func getOutputFormat() string {
+	for _, arg := range os.Args {
+		if strings.HasPrefix(arg, "--output=") {
+			return strings.TrimPrefix(arg, "--output=")
+		}
+	}
+
+	return outputFormatText
+}
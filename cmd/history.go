@@ -0,0 +1,317 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/history"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// runIDLayout is the layout of the time, in UTC, from which each run's ID is formatted.
+// It is kept short because the run ID is part of the import ID of each transaction, which YNAB limits to 36 characters.
+const runIDLayout = "20060102T150405Z"
+
+// runRecorder accumulates the record of a run as its transactions are created in YNAB.
+type runRecorder struct {
+	run              *history.Run
+	appConfig        *config.Config
+	accountNamesByID map[string]string
+	nextImportIndex  int
+}
+
+func newRunRecorder(
+	clock civil.Clock,
+	appConfig *config.Config,
+	budgetID string,
+	accountNamesByID map[string]string,
+	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
+	adjustmentsByAccountID map[string]*cliynab.MinimumBalanceAdjustment,
+	sweepsByAccountID map[string]*cliynab.BalanceSweep,
+	startDate, endDate civil.Date,
+) *runRecorder {
+	now := clock.Now()
+
+	fundingByAccountID := make(map[string]*history.AccountFunding)
+	getFunding := func(accountID string) *history.AccountFunding {
+		if _, hasFunding := fundingByAccountID[accountID]; !hasFunding {
+			fundingByAccountID[accountID] = &history.AccountFunding{Account: accountNamesByID[accountID]}
+		}
+
+		return fundingByAccountID[accountID]
+	}
+
+	for accountID, outboundBalance := range outboundBalances {
+		getFunding(accountID).OutboundCents = outboundBalance.ToCents()
+	}
+
+	for accountID, adjustment := range adjustmentsByAccountID {
+		funding := getFunding(accountID)
+		funding.AdjustmentCents = adjustment.ToCents()
+		funding.AdjustmentRule = adjustment.Rule
+	}
+
+	for accountID, sweep := range sweepsByAccountID {
+		getFunding(accountID).SweepCents = sweep.ToCents()
+	}
+
+	accounts := make([]history.AccountFunding, 0, len(fundingByAccountID))
+	for _, funding := range fundingByAccountID {
+		accounts = append(accounts, *funding)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Account < accounts[j].Account
+	})
+
+	return &runRecorder{
+		run: &history.Run{
+			ID:        now.UTC().Format(runIDLayout),
+			Timestamp: now,
+			BudgetID:  budgetID,
+			StartDate: startDate,
+			EndDate:   endDate,
+			Accounts:  accounts,
+		},
+		appConfig:        appConfig,
+		accountNamesByID: accountNamesByID,
+	}
+}
+
+// assignImportIDs gives each of the given transactions an import ID that identifies it as having been created by this run.
+func (r *runRecorder) assignImportIDs(transactions []cliynab.SaveTransaction) {
+	for transactionIndex := range transactions {
+		transactions[transactionIndex].ImportId = cliynab.ImportID(r.run.ID, r.nextImportIndex)
+		r.nextImportIndex++
+	}
+}
+
+// recordSaved records those of the given transactions that were created, with the IDs YNAB assigned to them.
+func (r *runRecorder) recordSaved(transactions []cliynab.SaveTransaction, saved *cliynab.SavedTransactions) {
+	transactionIDsByImportID := make(map[string]string, len(saved.Transactions))
+	for _, savedTransaction := range saved.Transactions {
+		if savedTransaction.ImportId != nil {
+			transactionIDsByImportID[*savedTransaction.ImportId] = savedTransaction.Id
+		}
+	}
+
+	for _, transaction := range transactions {
+		if _, isCreated := transactionIDsByImportID[transaction.ImportId]; !isCreated {
+			continue
+		}
+
+		r.run.Transactions = append(r.run.Transactions, history.Transaction{
+			ID:               transactionIDsByImportID[transaction.ImportId],
+			ImportID:         transaction.ImportId,
+			AccountID:        transaction.AccountId,
+			Account:          r.accountNamesByID[transaction.AccountId],
+			AmountMilliunits: transaction.Amount,
+			Date:             transaction.Date,
+			Memo:             transaction.Memo,
+		})
+	}
+}
+
+// save appends the run to the history file.
+func (r *runRecorder) save(ctx context.Context, qrURL string, totalCents int) {
+	r.run.QRURL = qrURL
+	r.run.TotalCents = totalCents
+
+	appendHistory(ctx, r.appConfig, r.run)
+}
+
+// appendHistory records the given run in the history file. The run's changes have already been made in YNAB,
+// so a failure to record it is reported rather than panicking.
+func appendHistory(ctx context.Context, appConfig *config.Config, run *history.Run) {
+	logger := logging.FromContext(ctx)
+
+	configHash, err := hashConfigFile(getConfigFile())
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to hash the configuration file for the run history", "error", err)
+	}
+	run.ConfigHash = configHash
+
+	historyFile := resolveConfigRelativePath(appConfig.GetHistoryFile())
+	if err := history.Append(historyFile, run); err != nil {
+		logger.ErrorContext(ctx, "Failed to record the run in the history",
+			"run_id", run.ID,
+			"history_file", historyFile,
+			"error", err)
+		return
+	}
+
+	fmt.Printf("Recorded run %s in the history\n", run.ID)
+}
+
+// hashConfigFile returns the hex-encoded SHA-256 hash of the given configuration file.
+func hashConfigFile(file string) (string, error) {
+	fileBytes, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file '%s': %w", file, err)
+	}
+
+	hash := sha256.Sum256(fileBytes)
+
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// runHistory lists, shows, or compares the runs recorded in the history.
+func runHistory() {
+	appConfig, err := readConfiguration(getConfigFile())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read configuration: %v", err))
+	}
+
+	runs := loadHistory(appConfig)
+
+	args := getCommandArgs()
+	subcommand := "list"
+	if len(args) > 0 {
+		subcommand = args[0]
+	}
+
+	switch subcommand {
+	case "list":
+		printRunList(runs)
+	case "show":
+		if len(args) != 2 {
+			panic("Usage: history show <run ID>")
+		}
+
+		printRun(findRun(runs, args[1]))
+	case "diff":
+		if len(args) != 3 {
+			panic("Usage: history diff <run ID> <run ID>")
+		}
+
+		printRunDiff(findRun(runs, args[1]), findRun(runs, args[2]))
+	default:
+		panic(fmt.Sprintf("Unsupported history command: '%s'", subcommand))
+	}
+}
+
+func loadHistory(appConfig *config.Config) []*history.Run {
+	runs, err := history.Load(resolveConfigRelativePath(appConfig.GetHistoryFile()))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load history: %v", err))
+	}
+
+	return runs
+}
+
+func findRun(runs []*history.Run, runID string) *history.Run {
+	run, err := history.Find(runs, runID)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to find run: %v", err))
+	}
+
+	return run
+}
+
+func printRunList(runs []*history.Run) {
+	if len(runs) == 0 {
+		fmt.Println("No runs have been recorded")
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "RUN ID\tRUN AT\tWINDOW\tTOTAL\tTRANSACTIONS")
+	for _, run := range runs {
+		window := run.StartDate.String() + " - " + run.EndDate.String()
+		if run.RevertedRun != "" {
+			window = "revert of " + run.RevertedRun
+			if run.Incomplete {
+				window += " (incomplete)"
+			}
+		} else if run.Incomplete {
+			window += " (incomplete)"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\n",
+			run.ID,
+			run.Timestamp.Local().Format("2006-01-02 15:04"),
+			window,
+			currency.FormatCents(run.TotalCents),
+			len(run.Transactions))
+	}
+	_ = writer.Flush()
+}
+
+func printRun(run *history.Run) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Run ID:\t%s\n", run.ID)
+	fmt.Fprintf(writer, "Run at:\t%s\n", run.Timestamp.Local().Format("2006-01-02 15:04:05 MST"))
+	if run.RevertedRun != "" {
+		fmt.Fprintf(writer, "Reverts:\t%s\n", run.RevertedRun)
+	}
+	if run.Incomplete && run.RevertedRun != "" {
+		fmt.Fprintln(writer, "Incomplete:\tnot all of the reverted run's transactions were deleted")
+	} else if run.Incomplete {
+		fmt.Fprintln(writer, "Incomplete:\tnot all of the run's transactions were created")
+	}
+	fmt.Fprintf(writer, "Config hash:\t%s\n", run.ConfigHash)
+	fmt.Fprintf(writer, "Window:\t%s - %s\n", run.StartDate.String(), run.EndDate.String())
+	fmt.Fprintf(writer, "Total:\t%s\n", currency.FormatCents(run.TotalCents))
+	if run.QRURL != "" {
+		fmt.Fprintf(writer, "QR URL:\t%s\n", run.QRURL)
+	}
+	_ = writer.Flush()
+
+	fmt.Println("Accounts:")
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "  ACCOUNT\tOUTBOUND\tADJUSTMENT\tSWEEP\tRULE")
+	for _, account := range run.Accounts {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n",
+			account.Account,
+			currency.FormatCents(account.OutboundCents),
+			currency.FormatCents(account.AdjustmentCents),
+			currency.FormatCents(account.SweepCents),
+			account.AdjustmentRule)
+	}
+	_ = writer.Flush()
+
+	fmt.Println("Transactions:")
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "  ACCOUNT\tDATE\tAMOUNT\tID\tMEMO")
+	for _, transaction := range run.Transactions {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\t%s\n",
+			transaction.Account,
+			transaction.Date,
+			currency.FormatCents(currency.MilliunitsToCents(transaction.AmountMilliunits)),
+			transaction.ID,
+			transaction.Memo)
+	}
+	_ = writer.Flush()
+}
+
+func printRunDiff(from, to *history.Run) {
+	differences := history.Diff(from, to)
+	if len(differences) == 0 {
+		fmt.Printf("Runs %s and %s do not differ\n", from.ID, to.ID)
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "FIELD\t%s\t%s\n", from.ID, to.ID)
+	for _, difference := range differences {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", difference.Field, valueOrNone(difference.From), valueOrNone(difference.To))
+	}
+	_ = writer.Flush()
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+
+	return value
+}
@@ -6,60 +6,140 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
 	"github.com/jrh3k5/oauth-cli/pkg/auth"
 	"github.com/manifoldco/promptui"
 	"github.com/mdp/qrterminal"
-	"gopkg.in/yaml.v3"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/qr"
-
+	"github.com/jrh3k5/cryptonabber-offramp/v3/state"
 	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
 func main() {
-	ctx := context.Background()
+	logger, err := logging.NewLogger(os.Stderr, getLogFormat(), getLogLevel())
+	if err != nil {
+		panic(fmt.Sprintf("Failed to configure logging: %v", err))
+	}
+
+	ctx := logging.WithLogger(context.Background(), logger)
 
+	logger.DebugContext(ctx, "Debug logging enabled")
+
+	clock := getClock()
+
+	switch command := getCommand(); command {
+	case "", "run":
+		runOfframp(ctx, clock)
+	case "forecast":
+		runForecast(ctx, clock)
+	case "history":
+		runHistory()
+	case "revert":
+		runRevert(ctx, clock)
+	default:
+		panic(fmt.Sprintf("Unsupported command: '%s'", command))
+	}
+}
+
+// runOfframp calculates the funding needed for the offramp accounts, records the transfers in YNAB,
+// and presents the QR code with which to send the funds.
+func runOfframp(ctx context.Context, clock civil.Clock) {
 	dryRun := isDryRun()
 	if dryRun {
 		fmt.Println("Dry run enabled; will not create transactions in YNAB")
+	} else if _, hasAsOf := getAsOfDate(); hasAsOf {
+		// Recalculating a past date is for review only; the transfers it would create are already in the past
+		dryRun = true
+		fmt.Println("Calculating as of a past date; will not create transactions in YNAB")
 	}
 
-	debug := isDebug()
-	if debug {
-		fmt.Println("Debug mode enabled")
-	}
+	apiClient, budget, appConfig := setupYNABClient(ctx)
 
-	ynabClient, budget, appConfig := setupYNABClient(ctx)
+	budgetData := loadBudgetData(ctx, apiClient, budget.Id, appConfig)
 
-	accountInfo := resolveAccountInfo(ynabClient, budget.Id, appConfig)
+	accountInfo := resolveAccountInfo(budgetData.Accounts.Items, appConfig)
 
 	urlGenerator := createURLGenerator(appConfig)
 
-	startDate, endDate := promptForDateRange()
+	businessCalendar := loadCalendar(appConfig)
+
+	arrivalDate := calculateArrivalDate(ctx, clock, appConfig, businessCalendar)
+
+	fundingState := loadState(appConfig)
 
-	scheduledTransactions := getScheduledTransactions(ynabClient, budget.Id)
+	startDate, endDate := promptForDateRange(clock, appConfig)
+
+	// Every window covered by this run is recorded as funded once the run has handled it, even if it needed no funding
+	var fundedWindows []state.Window
+	if isCatchUp() {
+		fundedWindows = catchUpWindows(ctx, clock, appConfig, fundingState, startDate)
+	}
+	fundedWindows = append(fundedWindows, state.Window{
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+
+	// the missed windows and the target window are funded together
+	startDate = fundedWindows[0].StartDate
+
+	// Entered transactions are needed from the start of the window and, for projections, from tomorrow
+	transactionsSinceDate := civil.Today(clock).AddDays(1)
+	if startDate.Before(transactionsSinceDate) {
+		transactionsSinceDate = startDate
+	}
+	accountInfo.transactionsByAccountID = getAccountTransactions(ctx, apiClient, budget.Id, accountInfo, transactionsSinceDate)
+
+	if startDate.Before(arrivalDate) {
+		logging.FromContext(ctx).WarnContext(ctx, "The window starts before funds sent today are expected to arrive; transactions before the arrival date may not be covered",
+			"start_date", startDate.String(),
+			"arrival_date", arrivalDate.String())
+	}
+
+	scheduledTransactions := shiftBillsToBusinessDays(ctx, appConfig, businessCalendar, accountInfo, budgetData.ScheduledTransactions.Items)
 
 	outboundBalances, adjustmentsByAccountID := calculateBalances(
-		ynabClient,
+		ctx,
+		clock,
+		apiClient,
 		budget.Id,
 		appConfig,
 		accountInfo,
 		scheduledTransactions,
 		startDate,
 		endDate,
-		debug,
 	)
 
 	outboundCents := displayBalances(outboundBalances, adjustmentsByAccountID, accountInfo.accountNamesByID, startDate, endDate)
 
-	if outboundCents == 0 {
+	sweepsByAccountID := calculateMaximumBalanceSweeps(
+		ctx,
+		clock,
+		appConfig,
+		accountInfo,
+		math.MirrorInternalTransfers(accountInfo.offrampAccountIDs, scheduledTransactions),
+		outboundBalances,
+		adjustmentsByAccountID,
+		endDate,
+	)
+
+	sweepCents := displaySweeps(sweepsByAccountID, accountInfo.accountNamesByID)
+
+	if outboundCents == 0 && sweepCents == 0 {
+		if !dryRun {
+			// The windows needed no funding, but they were handled, so --catch-up must not treat them as missed
+			recordFundedWindows(ctx, clock, appConfig, fundingState, fundedWindows)
+		}
+
 		fmt.Println("No upcoming transactions require funding; exiting")
 		return
 	}
@@ -69,30 +149,93 @@ func main() {
 		return
 	}
 
-	createTransactionsAndGenerateQR(
-		ctx,
-		ynabClient,
-		budget.Id,
+	// Generate the QR code's URL before anything is written to YNAB, so that a failure to generate it leaves YNAB untouched
+	var qrURL string
+	if outboundCents > 0 {
+		qrURL = generateQRURL(ctx, appConfig, outboundCents, urlGenerator)
+	}
+
+	recorder := newRunRecorder(
+		clock,
 		appConfig,
-		accountInfo,
+		budget.Id,
+		accountInfo.accountNamesByID,
 		outboundBalances,
 		adjustmentsByAccountID,
+		sweepsByAccountID,
 		startDate,
 		endDate,
-		outboundCents,
-		urlGenerator,
 	)
+
+	if sweepCents > 0 {
+		createSweepTransactions(
+			ctx,
+			clock,
+			apiClient,
+			budget.Id,
+			budgetData.Payees.Items,
+			accountInfo,
+			recorder,
+			sweepsByAccountID,
+			startDate,
+			endDate,
+		)
+	}
+
+	if outboundCents > 0 {
+		createTransactions(
+			ctx,
+			clock,
+			apiClient,
+			budget.Id,
+			budgetData.Payees.Items,
+			accountInfo,
+			recorder,
+			outboundBalances,
+			adjustmentsByAccountID,
+			startDate,
+			endDate,
+			arrivalDate,
+		)
+	}
+
+	recordFundedWindows(ctx, clock, appConfig, fundingState, fundedWindows)
+
+	recorder.save(ctx, qrURL, outboundCents)
+
+	if outboundCents == 0 {
+		fmt.Println("No upcoming transactions require funding; exiting")
+		return
+	}
+
+	displayQR(outboundCents, qrURL)
 }
 
+// creditCardPaymentsCategoryGroupName is the name of the category group in which YNAB keeps the payment category of each credit card.
+const creditCardPaymentsCategoryGroupName = "Credit Card Payments"
+
 type accountInfoData struct {
 	allAccountIDs        []string
 	offrampAccountIDs    []string
 	fundsOriginAccountID string
 	recipientAccountID   string
 	accountNamesByID     map[string]string
+	accountsByID         map[string]cliynab.Account // the configured accounts, as last fetched from YNAB
+	// the transactions entered in the offramp accounts, fetched once per run by getAccountTransactions
+	transactionsByAccountID map[string][]cliynab.TransactionDetail
 }
 
-func setupYNABClient(ctx context.Context) (*ynab.Client, *ynab.BudgetSummary, *config.Config) {
+func setupYNABClient(ctx context.Context) (cliynab.API, *cliynab.BudgetSummary, *config.Config) {
+	// Read the configuration before authenticating so that mistakes in it are reported
+	// before the user has to go through the OAuth flow
+	file := getConfigFile()
+	logging.FromContext(ctx).InfoContext(ctx, "Reading configuration", "file", file)
+
+	appConfig, err := readConfiguration(file)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read configuration: %v", err))
+	}
+
 	oauthToken, err := auth.DefaultGetOAuthToken(ctx,
 		"https://app.ynab.com/oauth/authorize",
 		"https://api.ynab.com/oauth/token",
@@ -101,22 +244,18 @@ func setupYNABClient(ctx context.Context) (*ynab.Client, *ynab.BudgetSummary, *c
 		panic(fmt.Sprintf("Failed to get OAuth token: %v", err))
 	}
 
-	file := getConfigFile()
-	fmt.Printf("Reading configuration from '%s'\n", file)
-
-	appConfig, err := readConfiguration(file)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to read configuration: %v", err))
-	}
-
 	ynabURL, err := url.Parse("https://api.ynab.com/v1/")
 	if err != nil {
 		// ??? how?
 		panic(fmt.Sprintf("unable to parse hard-coded YNAB URL: %v", err))
 	}
-	ynabClient := ynab.NewClient(ynabURL, http.DefaultClient, oauthToken.AccessToken)
+	// Retry transient failures and rate limiting, and bound how long each request can take, so that the tool never hangs
+	httpClient := &http.Client{
+		Transport: cliynab.NewRetryingTransport(http.DefaultTransport, logging.FromContext(ctx)),
+	}
+	apiClient := cliynab.NewClient(ynabURL, httpClient, oauthToken.AccessToken)
 
-	budget, err := getBudget(ynabClient, appConfig.YNABBudgetName)
+	budget, err := getBudget(ctx, apiClient, appConfig.YNABBudgetName)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to get budget: %v", err))
 	}
@@ -124,19 +263,29 @@ func setupYNABClient(ctx context.Context) (*ynab.Client, *ynab.BudgetSummary, *c
 		panic(fmt.Sprintf("No budget found for name '%s'", appConfig.YNABBudgetName))
 	}
 
-	return ynabClient, budget, appConfig
+	return apiClient, budget, appConfig
 }
 
-func resolveAccountInfo(ynabClient *ynab.Client, budgetID string, appConfig *config.Config) accountInfoData {
+func resolveAccountInfo(accounts []cliynab.Account, appConfig *config.Config) accountInfoData {
 	offrampAccountNames := make([]string, len(appConfig.YNABAccounts.OfframpAccounts))
 	for accountIndex, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
 		offrampAccountNames[accountIndex] = offrampAccount.Name
 	}
 
-	allAccountNames := toUnique(append(offrampAccountNames, appConfig.YNABAccounts.FundsOriginAccount, appConfig.YNABAccounts.FundsRecipientAccount))
-	accountNamesByID, err := mapAccountNamesByID(ynabClient, budgetID, allAccountNames)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to map offramp accounts by ID: %v", err))
+	allAccountNames := append(offrampAccountNames, appConfig.YNABAccounts.FundsOriginAccount, appConfig.YNABAccounts.FundsRecipientAccount)
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		if offrampAccount.SweepAccount != nil {
+			allAccountNames = append(allAccountNames, *offrampAccount.SweepAccount)
+		}
+	}
+	allAccountNames = toUnique(allAccountNames)
+	accountNamesByID := mapAccountNamesByID(accounts, allAccountNames)
+
+	accountsByID := make(map[string]cliynab.Account, len(accountNamesByID))
+	for _, account := range accounts {
+		if _, isConfigured := accountNamesByID[account.Id]; isConfigured {
+			accountsByID[account.Id] = account
+		}
 	}
 
 	allAccountIDs, err := getAccountIDs(accountNamesByID, allAccountNames)
@@ -158,6 +307,7 @@ func resolveAccountInfo(ynabClient *ynab.Client, budgetID string, appConfig *con
 		fundsOriginAccountID: fundsOriginAccountID,
 		recipientAccountID:   recipientAccountID,
 		accountNamesByID:     accountNamesByID,
+		accountsByID:         accountsByID,
 	}
 }
 
@@ -172,149 +322,286 @@ func resolveSingleAccountID(accountNamesByID map[string]string, accountName, acc
 func createURLGenerator(appConfig *config.Config) qr.URLGenerator {
 	qrCodeType := appConfig.GetQRCodeType()
 	switch qrCodeType {
-	case "erc681":
+	case config.QRCodeTypeERC681:
 		return qr.NewERC681URLGenerator()
-	case "recipient_only":
+	case config.QRCodeTypeRecipientOnly:
 		return qr.NewRecipientAddressURLGenerator()
 	default:
 		panic(fmt.Sprintf("Unsupported QR code type: %v", qrCodeType))
 	}
 }
 
-func promptForDateRange() (time.Time, time.Time) {
-	now := time.Now().Local()
-	nowDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-	startDate := nowDay.Add(7 * 24 * time.Hour)
+func promptForDateRange(clock civil.Clock, appConfig *config.Config) (civil.Date, civil.Date) {
+	defaultStartDate, defaultEndDate := getDefaultDateRange(clock, appConfig)
+
+	startDate := promptForDate("Start date", defaultStartDate)
+	// keep the default window's length if the start date is changed
+	endDate := promptForDate("End date", startDate.AddDays(defaultEndDate.DaysSince(defaultStartDate)))
+
+	return startDate, endDate
+}
 
+func promptForDate(label string, defaultDate civil.Date) civil.Date {
 	isValidDate := func(v string) error {
-		_, parseErr := time.Parse(time.DateOnly, v)
+		_, parseErr := civil.ParseDate(v)
 		return parseErr
 	}
 
-	startDatePrompt := &promptui.Prompt{
-		Label:    "Start date",
-		Default:  startDate.Format(time.DateOnly),
-		Validate: isValidDate,
-	}
-	startDateStr, startDatePromptErr := startDatePrompt.Run()
-	if startDatePromptErr != nil {
-		panic(fmt.Sprintf("Failed to get start date: %v", startDatePromptErr))
-	}
-	// the Validate function in the prompt ensures that it's a valid date value
-	startDate, _ = time.Parse(time.DateOnly, startDateStr)
-
-	endDate := startDate.Add(6 * 24 * time.Hour)
-	endDatePrompt := &promptui.Prompt{
-		Label:    "End date",
-		Default:  endDate.Format(time.DateOnly),
+	datePrompt := &promptui.Prompt{
+		Label:    label,
+		Default:  defaultDate.String(),
 		Validate: isValidDate,
 	}
-	endDateStr, endDatePromptErr := endDatePrompt.Run()
-	if endDatePromptErr != nil {
-		panic(fmt.Sprintf("Failed to get end date: %v", endDatePromptErr))
+	dateStr, datePromptErr := datePrompt.Run()
+	if datePromptErr != nil {
+		panic(fmt.Sprintf("Failed to get %s: %v", strings.ToLower(label), datePromptErr))
 	}
 	// the Validate function in the prompt ensures that it's a valid date value
-	endDate, _ = time.Parse(time.DateOnly, endDateStr)
+	date, _ := civil.ParseDate(dateStr)
 
-	return startDate, endDate
-}
-
-func getScheduledTransactions(ynabClient *ynab.Client, budgetID string) []ynab.ScheduledTransactionDetail {
-	scheduledTransactions, err := ynabClient.ScheduledTransactionsService.List(budgetID)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to get scheduled transactions: %v", err))
-	}
-	return scheduledTransactions
+	return date
 }
 
 func calculateBalances(
-	ynabClient *ynab.Client,
+	ctx context.Context,
+	clock civil.Clock,
+	apiClient cliynab.API,
 	budgetID string,
 	appConfig *config.Config,
 	accountInfo accountInfoData,
-	scheduledTransactions []ynab.ScheduledTransactionDetail,
-	startDate, endDate time.Time,
-	debug bool,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+	startDate, endDate civil.Date,
 ) (map[string]*cliynab.OutboundTransactionBalance, map[string]*cliynab.MinimumBalanceAdjustment) {
-	excludedColorsByAccountID := buildExcludedColorMap(appConfig, accountInfo.accountNamesByID)
+	flagColorsByAccountID := buildFlagColorMap(appConfig, accountInfo.accountNamesByID)
 
-	outboundBalances, err := math.CalculateOutboundTransactions(
-		accountInfo.offrampAccountIDs,
-		excludedColorsByAccountID,
-		scheduledTransactions,
+	var categoryGroups []cliynab.CategoryGroup
+	if usesCategories(appConfig) {
+		categoryGroups = getCategoryGroups(ctx, apiClient, budgetID)
+	}
+
+	transactionRules := buildTransactionRules(appConfig, accountInfo.accountNamesByID, categoryGroups)
+
+	enteredTransactions := getEnteredTransactions(ctx, accountInfo, startDate)
+	outboundTransactions := math.MergeEnteredTransactions(scheduledTransactions, enteredTransactions)
+
+	if isExplain() {
+		exclusions, err := math.ExplainExclusions(
+			accountInfo.offrampAccountIDs,
+			flagColorsByAccountID,
+			transactionRules,
+			outboundTransactions,
+			startDate,
+			endDate,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to explain excluded transactions: %v", err))
+		}
+
+		displayExclusions(exclusions, accountInfo.accountNamesByID)
+	}
+
+	// Projections of each account's balance need to see the money that the other offramp accounts send to it
+	projectionTransactions := math.MirrorInternalTransfers(accountInfo.offrampAccountIDs, scheduledTransactions)
+
+	scheduledFundingSource := &math.ScheduledFundingSource{
+		AccountIDs:            accountInfo.offrampAccountIDs,
+		FlagColorsByAccountID: flagColorsByAccountID,
+		Rules:                 transactionRules,
+		Transactions:          outboundTransactions,
+		StartDate:             startDate,
+		EndDate:               endDate,
+	}
+
+	outboundBalances := calculateFunding(
+		ctx,
+		clock,
+		appConfig,
+		accountInfo,
+		categoryGroups,
+		scheduledFundingSource,
+		projectionTransactions,
+	)
+
+	adjustmentsByAccountID := calculateMinimumBalanceAdjustments(
+		ctx,
+		clock,
+		appConfig,
+		accountInfo,
+		projectionTransactions,
 		startDate,
 		endDate,
 	)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to calculate outbound transactions: %v", err))
-	}
 
-	adjustmentsByAccountID := calculateMinimumBalanceAdjustments(
-		ynabClient,
-		budgetID,
+	applyNetFunding(
+		ctx,
+		clock,
 		appConfig,
-		accountInfo.accountNamesByID,
-		scheduledTransactions,
+		accountInfo,
+		projectionTransactions,
+		outboundBalances,
+		startDate,
+		endDate,
+	)
+
+	applyCreditCardFunding(
+		ctx,
+		appConfig,
+		accountInfo,
+		categoryGroups,
+		outboundTransactions,
+		outboundBalances,
+		startDate,
 		endDate,
-		debug,
 	)
 
 	return outboundBalances, adjustmentsByAccountID
 }
 
-func buildExcludedColorMap(appConfig *config.Config, accountNamesByID map[string]string) map[string][]string {
-	excludedColorsByAccountID := make(map[string][]string)
+// getAccountTransactions returns the transactions entered in each of the offramp accounts that are dated on or after the given date.
+// They are fetched once per run and shared by every calculation that needs them.
+func getAccountTransactions(
+	ctx context.Context,
+	apiClient cliynab.API,
+	budgetID string,
+	accountInfo accountInfoData,
+	sinceDate civil.Date,
+) map[string][]cliynab.TransactionDetail {
+	transactionsByAccountID := make(map[string][]cliynab.TransactionDetail, len(accountInfo.offrampAccountIDs))
+	for _, accountID := range accountInfo.offrampAccountIDs {
+		accountTransactions, err := apiClient.ListAccountTransactionsSince(ctx, budgetID, accountID, sinceDate)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to get entered transactions for account '%s' by ID '%s': %v", accountInfo.accountNamesByID[accountID], accountID, err))
+		}
+
+		transactionsByAccountID[accountID] = accountTransactions
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "retrieved account transactions", "accounts", len(transactionsByAccountID), "since_date", sinceDate.String())
+
+	return transactionsByAccountID
+}
+
+// getEnteredTransactions returns the transactions already entered (rather than scheduled) in the offramp accounts that are dated on or after the given start date.
+// Transfers to or from the other configured accounts, such as those recorded by previous runs, are omitted so that they are not mistaken for bills.
+func getEnteredTransactions(
+	ctx context.Context,
+	accountInfo accountInfoData,
+	startDate civil.Date,
+) []cliynab.TransactionDetail {
+	var enteredTransactions []cliynab.TransactionDetail
+	for _, accountID := range accountInfo.offrampAccountIDs {
+		for _, transaction := range accountInfo.transactionsByAccountID[accountID] {
+			if transaction.TransferAccountId != nil && slices.Contains(accountInfo.allAccountIDs, *transaction.TransferAccountId) {
+				continue
+			}
+
+			transactionDate, err := civil.ParseDate(transaction.Date)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to parse date of entered transaction ID '%s': %v", transaction.Id, err))
+			}
+
+			// the account's transactions may have been fetched from an earlier date than the window's start
+			if transactionDate.Before(startDate) {
+				continue
+			}
+
+			enteredTransactions = append(enteredTransactions, transaction)
+		}
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "retrieved entered transactions", "count", len(enteredTransactions), "since_date", startDate.String())
+
+	return enteredTransactions
+}
+
+// buildFlagColorMap maps the IDs of the offramp accounts to the flag colors that are included in or excluded from their calculations.
+func buildFlagColorMap(appConfig *config.Config, accountNamesByID map[string]string) map[string]*math.FlagColorFilter {
+	flagColorsByAccountID := make(map[string]*math.FlagColorFilter)
 	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
 		for accountID, accountName := range accountNamesByID {
 			if accountName == offrampAccount.Name {
-				excludedColorsByAccountID[accountID] = offrampAccount.ExcludedFlagColors
+				flagColorsByAccountID[accountID] = &math.FlagColorFilter{
+					Included: toFilterFlagColors(offrampAccount.IncludedFlagColors),
+					Excluded: toFilterFlagColors(offrampAccount.ExcludedFlagColors),
+				}
 			}
 		}
 	}
-	return excludedColorsByAccountID
+	return flagColorsByAccountID
+}
+
+// toFilterFlagColors converts configured flag colors to those matched by a math.FlagColorFilter,
+// which matches transactions without a flag color by an empty flag color.
+func toFilterFlagColors(flagColors []string) []string {
+	if flagColors == nil {
+		return nil
+	}
+
+	filterFlagColors := make([]string, len(flagColors))
+	for flagColorIndex, flagColor := range flagColors {
+		if strings.EqualFold(strings.TrimSpace(flagColor), config.FlagColorUnflagged) {
+			filterFlagColors[flagColorIndex] = ""
+		} else {
+			filterFlagColors[flagColorIndex] = flagColor
+		}
+	}
+
+	return filterFlagColors
 }
 
 func calculateMinimumBalanceAdjustments(
-	ynabClient *ynab.Client,
-	budgetID string,
+	ctx context.Context,
+	clock civil.Clock,
 	appConfig *config.Config,
-	accountNamesByID map[string]string,
-	scheduledTransactions []ynab.ScheduledTransactionDetail,
-	endDate time.Time,
-	debug bool,
+	accountInfo accountInfoData,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+	startDate, endDate civil.Date,
 ) map[string]*cliynab.MinimumBalanceAdjustment {
 	adjustmentsByAccountID := make(map[string]*cliynab.MinimumBalanceAdjustment)
 
 	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
-		minimumBalanceCents, hasMinimumBalance, err := offrampAccount.MinimumBalanceAsCents()
-		if err != nil {
-			panic(fmt.Sprintf("Failed to parse minimum balance for account '%s': %v", offrampAccount.Name, err))
-		}
-		if !hasMinimumBalance {
+		// Accounts funded by net funding maintain their minimum balance as part of that calculation
+		if !offrampAccount.HasMinimumBalanceRules() || offrampAccount.GetFundingMode() == config.FundingModeNet {
 			continue
 		}
 
+		minimumBalanceRules, err := buildMinimumBalanceRules(offrampAccount)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to resolve minimum balance rules for account '%s': %v", offrampAccount.Name, err))
+		}
+
 		createdAdjustment := false
-		for accountID, accountName := range accountNamesByID {
+		for accountID, accountName := range accountInfo.accountNamesByID {
 			if accountName != offrampAccount.Name {
 				continue
 			}
 
-			ynabAccount, err := ynabClient.AccountsService.Get(budgetID, accountID)
+			ynabAccount, accountTransactions := getProjectionAccount(ctx, clock, accountInfo, offrampAccount, accountID, scheduledTransactions)
+
+			minimumBalanceTarget, err := math.CalculateMinimumBalanceTarget(
+				ctx,
+				accountID,
+				accountTransactions,
+				minimumBalanceRules,
+				startDate,
+				endDate,
+			)
 			if err != nil {
-				panic(fmt.Sprintf("Failed to get account '%s' by ID '%s': %v", accountName, accountID, err))
+				panic(fmt.Sprintf("Failed to calculate minimum balance target for account '%s' by ID '%s': %v", accountName, accountID, err))
 			}
 
 			balanceAdjustment, err := math.CalculateMinimumBalanceAdjustment(
+				ctx,
+				clock,
 				ynabAccount,
-				scheduledTransactions,
-				minimumBalanceCents,
+				accountTransactions,
+				minimumBalanceTarget.Cents,
 				endDate,
-				debug,
 			)
 			if err != nil {
 				panic(fmt.Sprintf("Failed to calculate minimum balance adjustment for account '%s' by ID '%s': %v", accountName, accountID, err))
 			}
+			balanceAdjustment.Rule = minimumBalanceTarget.Rule
 
 			adjustmentsByAccountID[accountID] = balanceAdjustment
 			createdAdjustment = true
@@ -323,21 +610,293 @@ func calculateMinimumBalanceAdjustments(
 		}
 
 		if !createdAdjustment {
-			fmt.Printf("No balance adjustment created for account '%s'; its outbound balances will not reflect a minimum amount maintenance\n", offrampAccount.Name)
+			logging.FromContext(ctx).WarnContext(ctx, "No balance adjustment created; its outbound balances will not reflect a minimum amount maintenance", "account", offrampAccount.Name)
 		}
 	}
 
 	return adjustmentsByAccountID
 }
 
+// calculateFunding calculates the funding of each offramp account from its configured funding source.
+func calculateFunding(
+	ctx context.Context,
+	clock civil.Clock,
+	appConfig *config.Config,
+	accountInfo accountInfoData,
+	categoryGroups []cliynab.CategoryGroup,
+	scheduledFundingSource *math.ScheduledFundingSource,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+) map[string]*cliynab.OutboundTransactionBalance {
+	outboundBalances := make(map[string]*cliynab.OutboundTransactionBalance)
+
+	var categoriesByName map[string][]cliynab.Category
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		accountID := resolveSingleAccountID(accountInfo.accountNamesByID, offrampAccount.Name, "offramp")
+
+		var fundingSource math.FundingSource
+		// the scheduled funding source only needs to know which account it is funding
+		ynabAccount := cliynab.Account{Id: accountID, Name: offrampAccount.Name}
+
+		switch offrampAccount.GetFundingSource() {
+		case config.FundingSourceCategory:
+			if categoriesByName == nil {
+				categoriesByName = getCategoriesByName(categoryGroups)
+			}
+
+			categoryFundingSource := &math.CategoryFundingSource{}
+			for _, categoryName := range offrampAccount.FundingCategories {
+				categories := categoriesByName[categoryName]
+				switch len(categories) {
+				case 0:
+					panic(fmt.Sprintf("No category found for name '%s' funding account '%s'", categoryName, offrampAccount.Name))
+				case 1:
+					categoryFundingSource.Categories = append(categoryFundingSource.Categories, categories[0])
+				default:
+					panic(fmt.Sprintf("Multiple categories found for name '%s' funding account '%s'", categoryName, offrampAccount.Name))
+				}
+			}
+
+			fundingSource = categoryFundingSource
+			// the category funding source deducts what the account already holds
+			ynabAccount, _ = getProjectionAccount(ctx, clock, accountInfo, offrampAccount, accountID, scheduledTransactions)
+		default:
+			fundingSource = scheduledFundingSource
+		}
+
+		funding, err := fundingSource.CalculateFunding(ctx, ynabAccount)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to calculate funding for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+		}
+
+		outboundBalances[accountID] = funding
+	}
+
+	return outboundBalances
+}
+
+// getCategoryGroups returns the budget's category groups and their categories, with their balances for the current month.
+func getCategoryGroups(ctx context.Context, apiClient cliynab.API, budgetID string) []cliynab.CategoryGroup {
+	categoryGroups, err := apiClient.ListCategories(ctx, budgetID)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to get categories: %v", err))
+	}
+
+	return categoryGroups
+}
+
+// usesCategories returns true if any of the offramp accounts is funded from categories, is a credit card funded from its payment category,
+// or has a transaction rule that matches on category group, such that the budget's categories are needed.
+func usesCategories(appConfig *config.Config) bool {
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		if offrampAccount.GetFundingSource() == config.FundingSourceCategory || offrampAccount.GetType() == config.AccountTypeCreditCard {
+			return true
+		}
+
+		for _, rule := range offrampAccount.TransactionRules {
+			if rule.CategoryGroup != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getCategoriesByName maps the names of the given categories to the categories with that name.
+func getCategoriesByName(categoryGroups []cliynab.CategoryGroup) map[string][]cliynab.Category {
+	categoriesByName := make(map[string][]cliynab.Category)
+	for _, categoryGroup := range categoryGroups {
+		for _, category := range categoryGroup.Categories {
+			categoriesByName[category.Name] = append(categoriesByName[category.Name], category)
+		}
+	}
+
+	return categoriesByName
+}
+
+// applyNetFunding replaces the outbound balances of accounts using the net funding mode
+// with the shortfall needed to keep their projected balance above their minimum balance (or zero) on every day.
+func applyNetFunding(
+	ctx context.Context,
+	clock civil.Clock,
+	appConfig *config.Config,
+	accountInfo accountInfoData,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
+	startDate, endDate civil.Date,
+) {
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		if offrampAccount.GetFundingMode() != config.FundingModeNet {
+			continue
+		}
+
+		accountID := resolveSingleAccountID(accountInfo.accountNamesByID, offrampAccount.Name, "offramp")
+
+		ynabAccount, accountTransactions := getProjectionAccount(ctx, clock, accountInfo, offrampAccount, accountID, scheduledTransactions)
+
+		floorCents := 0
+		if offrampAccount.HasMinimumBalanceRules() {
+			minimumBalanceRules, err := buildMinimumBalanceRules(offrampAccount)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to resolve minimum balance rules for account '%s': %v", offrampAccount.Name, err))
+			}
+
+			minimumBalanceTarget, err := math.CalculateMinimumBalanceTarget(
+				ctx,
+				accountID,
+				accountTransactions,
+				minimumBalanceRules,
+				startDate,
+				endDate,
+			)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to calculate minimum balance target for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+			}
+
+			floorCents = minimumBalanceTarget.Cents
+		}
+
+		netFunding, err := math.CalculateNetFunding(ctx, clock, ynabAccount, accountTransactions, floorCents, endDate)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to calculate net funding for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+		}
+
+		outboundBalances[accountID] = netFunding
+	}
+}
+
+// getProjectionAccount returns the given offramp account, with its balance replaced by the balance from which projections start
+// according to its configured balance source, and the transactions to be projected for it.
+// The account's future-dated transactions are included in those transactions in place of any scheduled transactions they duplicate.
+func getProjectionAccount(
+	ctx context.Context,
+	clock civil.Clock,
+	accountInfo accountInfoData,
+	offrampAccount *config.YNABOfframpAccountConfig,
+	accountID string,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+) (cliynab.Account, []cliynab.ScheduledTransactionDetail) {
+	ynabAccount := getAccount(accountInfo, offrampAccount, accountID)
+
+	// ApplyBalanceSource ignores the transactions that are not dated after today
+	futureTransactions := accountInfo.transactionsByAccountID[accountID]
+
+	startingBalance, accountTransactions, err := math.ApplyBalanceSource(
+		clock,
+		ynabAccount,
+		math.BalanceSource(offrampAccount.GetBalanceSource()),
+		scheduledTransactions,
+		futureTransactions,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to apply balance source for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "resolved starting balance",
+		"account", offrampAccount.Name,
+		"balance_source", offrampAccount.GetBalanceSource(),
+		"entered_transactions", len(futureTransactions))
+
+	ynabAccount.Balance = startingBalance
+
+	return ynabAccount, accountTransactions
+}
+
+// getAccount returns the given offramp account as it was fetched from YNAB.
+func getAccount(accountInfo accountInfoData, offrampAccount *config.YNABOfframpAccountConfig, accountID string) cliynab.Account {
+	ynabAccount, hasAccount := accountInfo.accountsByID[accountID]
+	if !hasAccount {
+		panic(fmt.Sprintf("No account found for account '%s' by ID '%s'", offrampAccount.Name, accountID))
+	}
+
+	return ynabAccount
+}
+
+// applyCreditCardFunding replaces the outbound balances of credit card accounts with the payment of the card
+// funded by its payment category, rather than funding its charges.
+func applyCreditCardFunding(
+	ctx context.Context,
+	appConfig *config.Config,
+	accountInfo accountInfoData,
+	categoryGroups []cliynab.CategoryGroup,
+	transactions []cliynab.ScheduledTransactionDetail,
+	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
+	startDate, endDate civil.Date,
+) {
+	var paymentCategoriesByName map[string]cliynab.Category
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		if offrampAccount.GetType() != config.AccountTypeCreditCard {
+			continue
+		}
+
+		if paymentCategoriesByName == nil {
+			paymentCategoriesByName = getCreditCardPaymentCategories(categoryGroups)
+		}
+
+		paymentCategory, hasPaymentCategory := paymentCategoriesByName[offrampAccount.Name]
+		if !hasPaymentCategory {
+			panic(fmt.Sprintf("No credit card payment category found for account '%s'", offrampAccount.Name))
+		}
+
+		accountID := resolveSingleAccountID(accountInfo.accountNamesByID, offrampAccount.Name, "offramp")
+
+		ynabAccount := getAccount(accountInfo, offrampAccount, accountID)
+
+		funding, err := math.CalculateCreditCardFunding(ctx, ynabAccount, paymentCategory.Balance, transactions, startDate, endDate)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to calculate credit card funding for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+		}
+
+		outboundBalances[accountID] = funding
+	}
+}
+
+// getCreditCardPaymentCategories maps the names of the credit card payment categories among the given categories, which are named after their cards, to those categories.
+func getCreditCardPaymentCategories(categoryGroups []cliynab.CategoryGroup) map[string]cliynab.Category {
+	paymentCategoriesByName := make(map[string]cliynab.Category)
+	for _, categoryGroup := range categoryGroups {
+		if categoryGroup.Name != creditCardPaymentsCategoryGroupName {
+			continue
+		}
+
+		for _, category := range categoryGroup.Categories {
+			paymentCategoriesByName[category.Name] = category
+		}
+	}
+
+	return paymentCategoriesByName
+}
+
+// buildMinimumBalanceRules converts the minimum balance configuration of the given account
+// into the rules evaluated by the math package.
+func buildMinimumBalanceRules(offrampAccount *config.YNABOfframpAccountConfig) (*math.MinimumBalanceRules, error) {
+	rules := &math.MinimumBalanceRules{}
+
+	minimumBalanceCents, hasMinimumBalance, err := offrampAccount.MinimumBalanceAsCents()
+	if err != nil {
+		return nil, err
+	}
+	if hasMinimumBalance {
+		rules.FixedCents = &minimumBalanceCents
+	}
+
+	if targets := offrampAccount.MinimumBalanceTargets; targets != nil {
+		rules.OutflowPercentage = targets.OutflowPercentage
+		rules.LargestBill = targets.LargestBill
+		rules.CoverDaysBeyondWindow = targets.CoverDaysBeyondWindow
+	}
+
+	return rules, nil
+}
+
 func displayBalances(
 	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
 	adjustmentsByAccountID map[string]*cliynab.MinimumBalanceAdjustment,
 	accountNamesByID map[string]string,
-	startDate, endDate time.Time,
+	startDate, endDate civil.Date,
 ) int {
 	outboundCents := 0
-	fmt.Printf("Outbound Account Balances for [%s, %s]:\n", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
+	fmt.Printf("Outbound Account Balances for [%s, %s]:\n", startDate.String(), endDate.String())
 
 	for accountID, outboundBalance := range outboundBalances {
 		outboundBalanceCents := outboundBalance.ToCents()
@@ -354,7 +913,7 @@ func displayBalances(
 		if !hasAdjustment || balanceAdjustment.ToCents() == 0 {
 			fmt.Printf("  %s: %s\n", accountNamesByID[accountID], currency.FormatDollarsAndCents(totalDollars, totalCentsRemainder))
 		} else {
-			fmt.Printf("  %s: %s (bills: %s, balance adjustment %s)\n", accountNamesByID[accountID], currency.FormatDollarsAndCents(totalDollars, totalCentsRemainder), outboundBalance, balanceAdjustment)
+			fmt.Printf("  %s: %s (bills: %s, balance adjustment %s to meet %s)\n", accountNamesByID[accountID], currency.FormatDollarsAndCents(totalDollars, totalCentsRemainder), outboundBalance, balanceAdjustment, balanceAdjustment.Rule)
 		}
 
 		outboundCents += totalCents
@@ -363,23 +922,139 @@ func displayBalances(
 	return outboundCents
 }
 
-func createTransactionsAndGenerateQR(
+func calculateMaximumBalanceSweeps(
 	ctx context.Context,
-	ynabClient *ynab.Client,
-	budgetID string,
+	clock civil.Clock,
 	appConfig *config.Config,
 	accountInfo accountInfoData,
+	scheduledTransactions []cliynab.ScheduledTransactionDetail,
+	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
+	adjustmentsByAccountID map[string]*cliynab.MinimumBalanceAdjustment,
+	endDate civil.Date,
+) map[string]*cliynab.BalanceSweep {
+	sweepsByAccountID := make(map[string]*cliynab.BalanceSweep)
+
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		maximumBalanceCents, hasMaximumBalance, err := offrampAccount.MaximumBalanceAsCents()
+		if err != nil {
+			panic(fmt.Sprintf("Failed to parse maximum balance for account '%s': %v", offrampAccount.Name, err))
+		}
+		if !hasMaximumBalance {
+			continue
+		}
+
+		destinationAccountName := appConfig.YNABAccounts.FundsRecipientAccount
+		if offrampAccount.SweepAccount != nil {
+			destinationAccountName = *offrampAccount.SweepAccount
+		}
+		destinationAccountID := resolveSingleAccountID(accountInfo.accountNamesByID, destinationAccountName, "sweep destination")
+
+		accountID := resolveSingleAccountID(accountInfo.accountNamesByID, offrampAccount.Name, "offramp")
+
+		fundingCents := 0
+		if outboundBalance, hasBalance := outboundBalances[accountID]; hasBalance {
+			fundingCents += outboundBalance.ToCents()
+		}
+		if balanceAdjustment, hasAdjustment := adjustmentsByAccountID[accountID]; hasAdjustment {
+			fundingCents += balanceAdjustment.ToCents()
+		}
+
+		ynabAccount, accountTransactions := getProjectionAccount(ctx, clock, accountInfo, offrampAccount, accountID, scheduledTransactions)
+
+		sweep, err := math.CalculateMaximumBalanceSweep(
+			ctx,
+			clock,
+			ynabAccount,
+			accountTransactions,
+			fundingCents,
+			maximumBalanceCents,
+			endDate,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to calculate maximum balance sweep for account '%s' by ID '%s': %v", offrampAccount.Name, accountID, err))
+		}
+		sweep.DestinationAccountID = destinationAccountID
+
+		sweepsByAccountID[accountID] = sweep
+	}
+
+	return sweepsByAccountID
+}
+
+func displaySweeps(sweepsByAccountID map[string]*cliynab.BalanceSweep, accountNamesByID map[string]string) int {
+	sweepCents := 0
+	for accountID, sweep := range sweepsByAccountID {
+		if sweep.ToCents() == 0 {
+			continue
+		}
+
+		if sweepCents == 0 {
+			fmt.Println("Proposed sweeps of balances over their maximum:")
+		}
+
+		fmt.Printf("  %s -> %s: %s\n", accountNamesByID[accountID], accountNamesByID[sweep.DestinationAccountID], sweep)
+
+		sweepCents += sweep.ToCents()
+	}
+
+	return sweepCents
+}
+
+func createSweepTransactions(
+	ctx context.Context,
+	clock civil.Clock,
+	apiClient cliynab.API,
+	budgetID string,
+	payees []cliynab.Payee,
+	accountInfo accountInfoData,
+	recorder *runRecorder,
+	sweepsByAccountID map[string]*cliynab.BalanceSweep,
+	startDate, endDate civil.Date,
+) {
+	fmt.Println("Creating sweep transactions in YNAB...")
+
+	payeeIDsByAccountIDs, err := getTransferPayeeIDsByAccountID(
+		payees,
+		accountInfo.allAccountIDs,
+		accountInfo.accountNamesByID,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to resolve transfer payee IDs by account ID: %v", err))
+	}
+
+	transactions, err := cliynab.CreateSweepTransactions(
+		ctx,
+		clock,
+		sweepsByAccountID,
+		accountInfo.accountNamesByID,
+		payeeIDsByAccountIDs,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to create sweep transactions to send to YNAB: %v", err))
+	}
+
+	postTransactions(ctx, apiClient, budgetID, recorder, transactions, "sweep")
+}
+
+func createTransactions(
+	ctx context.Context,
+	clock civil.Clock,
+	apiClient cliynab.API,
+	budgetID string,
+	payees []cliynab.Payee,
+	accountInfo accountInfoData,
+	recorder *runRecorder,
 	outboundBalances map[string]*cliynab.OutboundTransactionBalance,
 	adjustmentsByAccountID map[string]*cliynab.MinimumBalanceAdjustment,
-	startDate, endDate time.Time,
-	outboundCents int,
-	urlGenerator qr.URLGenerator,
+	startDate, endDate civil.Date,
+	arrivalDate civil.Date,
 ) {
 	fmt.Println("Creating transactions in YNAB...")
 
 	payeeIDsByAccountIDs, err := getTransferPayeeIDsByAccountID(
-		ynabClient,
-		budgetID,
+		payees,
 		accountInfo.allAccountIDs,
 		accountInfo.accountNamesByID,
 	)
@@ -388,6 +1063,8 @@ func createTransactionsAndGenerateQR(
 	}
 
 	transactions, err := cliynab.CreateTransactions(
+		ctx,
+		clock,
 		accountInfo.fundsOriginAccountID,
 		accountInfo.recipientAccountID,
 		outboundBalances,
@@ -396,21 +1073,20 @@ func createTransactionsAndGenerateQR(
 		payeeIDsByAccountIDs,
 		startDate,
 		endDate,
+		arrivalDate,
 	)
 	if err != nil {
 		panic(fmt.Sprintf("Failed to create transactions to send to YNAB: %v", err))
 	}
 
-	_, err = ynabClient.TransactionsService.CreateBulk(budgetID, transactions)
-	if err != nil {
-		panic(fmt.Sprintf("Failed to create transfer transactions in YNAB: %v", err))
-	}
+	postTransactions(ctx, apiClient, budgetID, recorder, transactions, "transfer")
+}
 
+// generateQRURL generates the URL, to be presented as a QR code, with which to send the given total through the offramp.
+func generateQRURL(ctx context.Context, appConfig *config.Config, outboundCents int, urlGenerator qr.URLGenerator) string {
 	totalCents := outboundCents % 100
 	totalDollars := (outboundCents - totalCents) / 100
 
-	fmt.Printf("Scan the following QR code and send %s to the address it presents:\n", currency.FormatDollarsAndCents(totalDollars, totalCents))
-
 	qrDetails := &qr.Details{
 		ChainID:           appConfig.ChainID,
 		ContactAddress:    appConfig.ContractAddress,
@@ -425,6 +1101,13 @@ func createTransactionsAndGenerateQR(
 		panic(fmt.Sprintf("Failed to generate QR code URL: %v", err))
 	}
 
+	return url
+}
+
+// displayQR presents the QR code with which to send the given total through the offramp.
+func displayQR(outboundCents int, url string) {
+	fmt.Printf("Scan the following QR code and send %s to the address it presents:\n", currency.FormatCents(outboundCents))
+
 	qrterminal.Generate(url, qrterminal.M, os.Stdout)
 }
 
@@ -450,10 +1133,10 @@ func getAccountIDs(accountNamesByID map[string]string, accountNames []string) ([
 	return accountIDs, nil
 }
 
-func getBudget(ynabClient *ynab.Client, budgetName string) (*ynab.BudgetSummary, error) {
-	budgets, err := ynabClient.BudgetService.List()
+func getBudget(ctx context.Context, apiClient cliynab.API, budgetName string) (*cliynab.BudgetSummary, error) {
+	budgets, err := apiClient.ListBudgets(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list budgets: %w", err)
+		return nil, err
 	}
 
 	for _, budget := range budgets {
@@ -465,6 +1148,34 @@ func getBudget(ynabClient *ynab.Client, budgetName string) (*ynab.BudgetSummary,
 	return nil, nil
 }
 
+// getCommand returns the first argument that is not a flag, or an empty string if there is no such argument.
+func getCommand() string {
+	for _, arg := range os.Args[1:] {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+
+	return ""
+}
+
+// getCommandArgs returns the arguments that are not flags and follow the command, such as the run IDs given to the history command.
+func getCommandArgs() []string {
+	var commandArgs []string
+	for _, arg := range os.Args[1:] {
+		if !strings.HasPrefix(arg, "-") {
+			commandArgs = append(commandArgs, arg)
+		}
+	}
+
+	if len(commandArgs) == 0 {
+		return nil
+	}
+
+	// the first argument that is not a flag is the command itself
+	return commandArgs[1:]
+}
+
 func getConfigFile() string {
 	for _, arg := range os.Args {
 		if strings.HasPrefix(arg, "--file=") {
@@ -475,12 +1186,16 @@ func getConfigFile() string {
 	return "config.yaml"
 }
 
-func getTransferPayeeIDsByAccountID(ynabClient *ynab.Client, budgetID string, accountIDs []string, accountNamesByID map[string]string) (map[string]string, error) {
-	allPayees, err := ynabClient.PayeesService.List(budgetID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all payees: %w", err)
+// resolveConfigRelativePath resolves a relative path given in the configuration file against the directory of the configuration file.
+func resolveConfigRelativePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
 	}
 
+	return filepath.Join(filepath.Dir(getConfigFile()), path)
+}
+
+func getTransferPayeeIDsByAccountID(allPayees []cliynab.Payee, accountIDs []string, accountNamesByID map[string]string) (map[string]string, error) {
 	mappedPayeeIDs := make(map[string]string)
 	for _, accountID := range accountIDs {
 		accountName, hasAccountName := accountNamesByID[accountID]
@@ -503,6 +1218,33 @@ func getTransferPayeeIDsByAccountID(ynabClient *ynab.Client, budgetID string, ac
 	return mappedPayeeIDs, nil
 }
 
+// getClock returns the clock from which today's date is read: the date supplied by --as-of, if given,
+// or else the current date in the local time zone.
+func getClock() civil.Clock {
+	asOfDate, hasAsOf := getAsOfDate()
+	if !hasAsOf {
+		return civil.SystemClock{}
+	}
+
+	return civil.FixedClock{Time: asOfDate.In(time.Local)}
+}
+
+// getAsOfDate returns the date supplied by --as-of and true, or false if it was not supplied.
+func getAsOfDate() (civil.Date, bool) {
+	for _, arg := range os.Args {
+		if strings.HasPrefix(arg, "--as-of=") {
+			asOfDate, err := civil.ParseDate(strings.TrimPrefix(arg, "--as-of="))
+			if err != nil {
+				panic(fmt.Sprintf("Invalid --as-of date: %v", err))
+			}
+
+			return asOfDate, true
+		}
+	}
+
+	return civil.Date{}, false
+}
+
 func isDryRun() bool {
 	for _, arg := range os.Args {
 		if strings.HasPrefix(arg, "--dry-run=") {
@@ -513,22 +1255,33 @@ func isDryRun() bool {
 	return false
 }
 
-func isDebug() bool {
+// getLogLevel returns the level supplied by --log-level.
+// --debug is retained as a shorthand for --log-level=debug.
+func getLogLevel() string {
 	for _, arg := range os.Args {
 		if arg == "--debug" {
-			return true
+			return "debug"
+		}
+
+		if strings.HasPrefix(arg, "--log-level=") {
+			return strings.TrimPrefix(arg, "--log-level=")
 		}
 	}
 
-	return false
+	return "info"
 }
 
-func mapAccountNamesByID(ynabClient *ynab.Client, budgetID string, accountNames []string) (map[string]string, error) {
-	accounts, err := ynabClient.AccountsService.List(budgetID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list accounts: %w", err)
+func getLogFormat() string {
+	for _, arg := range os.Args {
+		if strings.HasPrefix(arg, "--log-format=") {
+			return strings.TrimPrefix(arg, "--log-format=")
+		}
 	}
 
+	return logging.FormatText
+}
+
+func mapAccountNamesByID(accounts []cliynab.Account, accountNames []string) map[string]string {
 	mapped := make(map[string]string)
 	for _, account := range accounts {
 		for _, accountName := range accountNames {
@@ -539,7 +1292,7 @@ func mapAccountNamesByID(ynabClient *ynab.Client, budgetID string, accountNames
 		}
 	}
 
-	return mapped, nil
+	return mapped
 }
 
 func readConfiguration(file string) (*config.Config, error) {
@@ -548,12 +1301,12 @@ func readConfiguration(file string) (*config.Config, error) {
 		return nil, fmt.Errorf("failed to read file '%s': %w", file, err)
 	}
 
-	config := &config.Config{}
-	if err := yaml.Unmarshal(fileBytes, config); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal YAML in file '%s': %w", file, err)
+	appConfig, err := config.Parse(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration in file '%s': %w", file, err)
 	}
 
-	return config, nil
+	return appConfig, nil
 }
 
 func toUnique(values []string) []string {
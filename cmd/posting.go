@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/manifoldco/promptui"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// postTransactions creates the given transactions in YNAB and records them in the run.
+// If not all of the run's transactions could be created - because the request failed, or because YNAB skipped some of them
+// as duplicates - then what was and wasn't created is reported, the transactions already created by the run
+// can be deleted, and this panics so that the run goes no further.
+func postTransactions(ctx context.Context, apiClient cliynab.API, budgetID string, recorder *runRecorder, transactions []cliynab.SaveTransaction, description string) {
+	recorder.assignImportIDs(transactions)
+
+	saved, err := apiClient.SaveTransactions(ctx, budgetID, transactions)
+	if err != nil {
+		// A timeout or transport error does not mean that YNAB created nothing, so look for the run's import IDs
+		// to find which of the transactions were created before reporting them or offering to delete them
+		uncreatedReason := "the request to create it failed"
+		created, lookupErr := findCreatedTransactions(ctx, apiClient, budgetID, transactions)
+		if lookupErr != nil {
+			logging.FromContext(ctx).ErrorContext(ctx, "Failed to look up which transactions were created after the request to create them failed",
+				"error", lookupErr)
+			uncreatedReason = "the request to create it failed, and whether it was created could not be checked"
+			created = &cliynab.SavedTransactions{}
+		}
+
+		recorder.recordSaved(transactions, created)
+
+		uncreated := cliynab.FindUncreatedTransactions(transactions, created)
+		for uncreatedIndex := range uncreated {
+			uncreated[uncreatedIndex].Reason = uncreatedReason
+		}
+
+		handleIncompletePosting(ctx, apiClient, budgetID, recorder, uncreated)
+
+		panic(fmt.Sprintf("Failed to create %s transactions in YNAB: %v", description, err))
+	}
+
+	recorder.recordSaved(transactions, saved)
+
+	if uncreated := cliynab.FindUncreatedTransactions(transactions, saved); len(uncreated) > 0 {
+		handleIncompletePosting(ctx, apiClient, budgetID, recorder, uncreated)
+
+		panic(fmt.Sprintf("%d of %d %s transactions were not created in YNAB", len(uncreated), len(transactions), description))
+	}
+}
+
+// findCreatedTransactions looks up, by their import IDs, which of the given transactions exist in YNAB.
+// It is used when the request to create them failed without saying which of them were created.
+func findCreatedTransactions(ctx context.Context, apiClient cliynab.API, budgetID string, transactions []cliynab.SaveTransaction) (*cliynab.SavedTransactions, error) {
+	importIDs := make(map[string]bool, len(transactions))
+	earliestDatesByAccountID := make(map[string]civil.Date)
+	for _, transaction := range transactions {
+		importIDs[transaction.ImportId] = true
+
+		transactionDate, err := civil.ParseDate(transaction.Date)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of transaction with import ID '%s': %w", transaction.ImportId, err)
+		}
+
+		if earliestDate, hasDate := earliestDatesByAccountID[transaction.AccountId]; !hasDate || transactionDate.Before(earliestDate) {
+			earliestDatesByAccountID[transaction.AccountId] = transactionDate
+		}
+	}
+
+	found := &cliynab.SavedTransactions{}
+	for accountID, earliestDate := range earliestDatesByAccountID {
+		accountTransactions, err := apiClient.ListAccountTransactionsSince(ctx, budgetID, accountID, earliestDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list transactions of account ID '%s': %w", accountID, err)
+		}
+
+		for _, accountTransaction := range accountTransactions {
+			if accountTransaction.ImportId != nil && importIDs[*accountTransaction.ImportId] {
+				found.Transactions = append(found.Transactions, accountTransaction)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// handleIncompletePosting reports which of the run's transactions were and weren't created and offers to delete those that were,
+// so that YNAB is not left with a partial record of the transfers. If they are kept, then the run is recorded in the history
+// as incomplete, so that it can be reverted later.
+func handleIncompletePosting(ctx context.Context, apiClient cliynab.API, budgetID string, recorder *runRecorder, uncreated []cliynab.UncreatedTransaction) {
+	created := recorder.run.Transactions
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Not all of the transactions could be created in YNAB.")
+	fmt.Fprintln(writer, "  STATUS\tACCOUNT\tAMOUNT\tDATE\tDETAIL")
+	for _, transaction := range created {
+		fmt.Fprintf(writer, "  created\t%s\t%s\t%s\t%s\n", transaction.Account, currency.FormatCents(currency.MilliunitsToCents(transaction.AmountMilliunits)), transaction.Date, transaction.ID)
+	}
+	for _, transaction := range uncreated {
+		fmt.Fprintf(writer, "  not created\t%s\t%s\t%s\t%s\n",
+			recorder.accountNamesByID[transaction.Transaction.AccountId],
+			currency.FormatCents(currency.MilliunitsToCents(transaction.Transaction.Amount)),
+			transaction.Transaction.Date,
+			transaction.Reason)
+	}
+	_ = writer.Flush()
+
+	if len(created) == 0 {
+		fmt.Println("No transactions were created in YNAB by this run")
+		return
+	}
+
+	rollbackPrompt := &promptui.Prompt{
+		Label:     fmt.Sprintf("Delete the %d transactions that were created", len(created)),
+		IsConfirm: true,
+	}
+	if _, err := rollbackPrompt.Run(); err != nil {
+		recorder.run.Incomplete = true
+		recorder.save(ctx, "", 0)
+		fmt.Printf("The created transactions were kept; they can be deleted later with: revert %s\n", recorder.run.ID)
+		return
+	}
+
+	logger := logging.FromContext(ctx)
+
+	var remaining []string
+	for _, transaction := range created {
+		if err := apiClient.DeleteTransaction(ctx, budgetID, transaction.ID); err != nil {
+			logger.ErrorContext(ctx, "Failed to delete a created transaction",
+				"account", transaction.Account,
+				"transaction_id", transaction.ID,
+				"error", err)
+			remaining = append(remaining, transaction.ID)
+		}
+	}
+
+	if len(remaining) > 0 {
+		// keep a record of the run so that the transactions that could not be deleted can be reverted later
+		recorder.run.Incomplete = true
+		recorder.save(ctx, "", 0)
+		fmt.Printf("%d of the created transactions could not be deleted; retry with: revert %s\n", len(remaining), recorder.run.ID)
+		return
+	}
+
+	fmt.Printf("Deleted the %d transactions that were created; YNAB is as it was before this run\n", len(created))
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/history"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// runRevert deletes the transactions created in YNAB by a recorded run, after verifying that each of them
+// is still as the run created it.
+func runRevert(ctx context.Context, clock civil.Clock) {
+	args := getCommandArgs()
+	if len(args) != 1 {
+		panic("Usage: revert <run ID>")
+	}
+	runID := args[0]
+
+	dryRun := isDryRun()
+	if dryRun {
+		fmt.Println("Dry run enabled; will not delete transactions in YNAB")
+	}
+
+	apiClient, budget, appConfig := setupYNABClient(ctx)
+
+	runs := loadHistory(appConfig)
+	run := findRun(runs, runID)
+
+	if run.RevertedRun != "" {
+		panic(fmt.Sprintf("Run %s is itself a revert and cannot be reverted", runID))
+	}
+
+	if reversal := history.FindReversal(runs, runID); reversal != nil {
+		panic(fmt.Sprintf("Run %s has already been reverted by run %s", runID, reversal.ID))
+	}
+
+	if run.BudgetID != budget.Id {
+		panic(fmt.Sprintf("Run %s was made against budget ID '%s', but the configured budget is ID '%s'", runID, run.BudgetID, budget.Id))
+	}
+
+	// a revert that failed partway through recorded the transactions it had already deleted
+	revertedImportIDs := history.RevertedImportIDs(runs, runID)
+	if len(revertedImportIDs) > 0 {
+		fmt.Printf("%d of the transactions created by run %s were deleted by an earlier revert that did not finish; they will be skipped\n", len(revertedImportIDs), runID)
+	}
+
+	transactionsToDelete, missingTransactions := verifyRunTransactions(ctx, apiClient, budget.Id, run, revertedImportIDs)
+
+	for _, missingTransaction := range missingTransactions {
+		fmt.Printf("Transaction %s in %s for %s no longer exists; it will be skipped\n",
+			missingTransaction.ImportID,
+			missingTransaction.Account,
+			currency.FormatCents(currency.MilliunitsToCents(missingTransaction.AmountMilliunits)))
+	}
+
+	if len(transactionsToDelete) == 0 && len(revertedImportIDs) == 0 {
+		fmt.Printf("None of the transactions created by run %s remain; there is nothing to revert\n", runID)
+		return
+	}
+
+	// if an earlier revert deleted all of the remaining transactions before failing, then this only completes it
+	if len(transactionsToDelete) > 0 {
+		fmt.Printf("The following transactions created by run %s for [%s, %s] will be deleted:\n", runID, run.StartDate.String(), run.EndDate.String())
+		for _, transaction := range transactionsToDelete {
+			fmt.Printf("  %s: %s on %s\n", transaction.Account, currency.FormatCents(currency.MilliunitsToCents(transaction.AmountMilliunits)), transaction.Date)
+		}
+	}
+
+	if dryRun {
+		return
+	}
+
+	if len(transactionsToDelete) > 0 {
+		confirmPrompt := &promptui.Prompt{
+			Label:     fmt.Sprintf("Delete these %d transactions", len(transactionsToDelete)),
+			IsConfirm: true,
+		}
+		if _, err := confirmPrompt.Run(); err != nil {
+			fmt.Println("Revert cancelled; no transactions were deleted")
+			return
+		}
+	}
+
+	revert := &history.Run{
+		ID:          clock.Now().UTC().Format(runIDLayout),
+		Timestamp:   clock.Now(),
+		BudgetID:    budget.Id,
+		StartDate:   run.StartDate,
+		EndDate:     run.EndDate,
+		RevertedRun: runID,
+	}
+
+	for transactionIndex, transaction := range transactionsToDelete {
+		if err := apiClient.DeleteTransaction(ctx, budget.Id, transaction.ID); err != nil {
+			// record what was deleted so that retrying the revert skips those transactions
+			if len(revert.Transactions) > 0 {
+				revert.Incomplete = true
+				appendHistory(ctx, appConfig, revert)
+			}
+
+			panic(fmt.Sprintf("Failed to delete transaction in %s after deleting %d of %d transactions; retry with: revert %s: %v",
+				transaction.Account,
+				transactionIndex,
+				len(transactionsToDelete),
+				runID,
+				err))
+		}
+
+		revert.Transactions = append(revert.Transactions, transaction)
+	}
+
+	fmt.Printf("Deleted %d transactions created by run %s\n", len(transactionsToDelete), runID)
+
+	// an incomplete run stops before recording its windows as funded; any other run recorded them, whatever it funded
+	if !run.Incomplete {
+		unrecordFundedWindows(ctx, appConfig, run.StartDate, run.EndDate)
+	}
+
+	appendHistory(ctx, appConfig, revert)
+}
+
+// verifyRunTransactions looks up each of the transactions created by the given run, returning those that still exist
+// (with the IDs by which they can be deleted) and those that no longer exist.
+// Transactions whose import IDs are among the given already-reverted import IDs are neither looked up nor returned.
+// If any transaction has changed since the run created it, this panics before any transaction is deleted.
+func verifyRunTransactions(ctx context.Context, apiClient cliynab.API, budgetID string, run *history.Run, revertedImportIDs map[string]bool) ([]history.Transaction, []history.Transaction) {
+	var existingTransactions []history.Transaction
+	var missingTransactions []history.Transaction
+	var verificationErrors []error
+
+	for _, transaction := range run.Transactions {
+		if revertedImportIDs[transaction.ImportID] {
+			continue
+		}
+
+		current, err := getRecordedTransaction(ctx, apiClient, budgetID, transaction)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to look up transaction %s: %v", transaction.ImportID, err))
+		}
+
+		if current == nil {
+			missingTransactions = append(missingTransactions, transaction)
+			continue
+		}
+
+		// the run may not have received the transaction's ID if it was found by its import ID
+		transaction.ID = current.Id
+
+		if err := transaction.Verify(*current); err != nil {
+			verificationErrors = append(verificationErrors, err)
+			continue
+		}
+
+		existingTransactions = append(existingTransactions, transaction)
+	}
+
+	if len(verificationErrors) > 0 {
+		for _, verificationError := range verificationErrors {
+			logging.FromContext(ctx).ErrorContext(ctx, "Transaction no longer matches what the run created", "error", verificationError)
+		}
+
+		panic(fmt.Sprintf("%d of the transactions created by run %s have changed since; no transactions were deleted", len(verificationErrors), run.ID))
+	}
+
+	return existingTransactions, missingTransactions
+}
+
+// getRecordedTransaction returns the transaction as it now exists in YNAB, or nil if it no longer exists.
+// Transactions whose IDs were not recorded are found by their import IDs among the transactions in their accounts.
+func getRecordedTransaction(ctx context.Context, apiClient cliynab.API, budgetID string, transaction history.Transaction) (*cliynab.TransactionDetail, error) {
+	if transaction.ID != "" {
+		return apiClient.GetTransaction(ctx, budgetID, transaction.ID)
+	}
+
+	transactionDate, err := civil.ParseDate(transaction.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse recorded date: %w", err)
+	}
+
+	accountTransactions, err := apiClient.ListAccountTransactionsSince(ctx, budgetID, transaction.AccountID, transactionDate)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, accountTransaction := range accountTransactions {
+		if accountTransaction.ImportId != nil && *accountTransaction.ImportId == transaction.ImportID {
+			return &accountTransaction, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/state"
+)
+
+// loadState reads the record of which windows have been funded.
+func loadState(appConfig *config.Config) *state.State {
+	fundingState, err := state.Load(resolveConfigRelativePath(appConfig.GetStateFile()))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load state: %v", err))
+	}
+
+	return fundingState
+}
+
+// catchUpWindows returns the windows that were missed between the last funded window and the given target window,
+// logging each of them. Windows that have already ended can no longer have their bills funded from scheduled transactions,
+// as YNAB will have already entered them, so a warning is logged for those.
+func catchUpWindows(ctx context.Context, clock civil.Clock, appConfig *config.Config, fundingState *state.State, targetStartDate civil.Date) []state.Window {
+	lastFundedEndDate, hasFunded := fundingState.LastFundedEndDate()
+	if !hasFunded {
+		fmt.Println("No windows have been recorded as funded; there are no missed windows to catch up on")
+		return nil
+	}
+
+	missedWindows := fundingState.UnfundedWindows(buildCatchUpCadence(appConfig, lastFundedEndDate), targetStartDate)
+	if len(missedWindows) == 0 {
+		fmt.Printf("The last funded window ended on %s; there are no missed windows to catch up on\n", lastFundedEndDate.String())
+		return nil
+	}
+
+	logger := logging.FromContext(ctx)
+	today := civil.Today(clock)
+
+	fmt.Printf("Catching up on %d missed window(s) since the last funded window ended on %s:\n", len(missedWindows), lastFundedEndDate.String())
+	for _, missedWindow := range missedWindows {
+		fmt.Printf("  %s - %s\n", missedWindow.StartDate.String(), missedWindow.EndDate.String())
+
+		if missedWindow.EndDate.Before(today) {
+			logger.WarnContext(ctx, "The missed window has already ended; bills within it that YNAB has already entered will not be funded",
+				"start_date", missedWindow.StartDate.String(),
+				"end_date", missedWindow.EndDate.String())
+		}
+	}
+
+	return missedWindows
+}
+
+// buildCatchUpCadence returns the cadence into which missed windows are split: the configured window's cadence or,
+// if none is configured, weeks starting the day after the last funded window.
+func buildCatchUpCadence(appConfig *config.Config, lastFundedEndDate civil.Date) calendar.Cadence {
+	if appConfig.Calendar != nil && appConfig.Calendar.Window != nil {
+		return buildCadence(appConfig.Calendar.Window)
+	}
+
+	return &calendar.PayPeriodCadence{
+		Anchor:     lastFundedEndDate.AddDays(1),
+		PeriodDays: 7,
+		Periods:    1,
+	}
+}
+
+// recordFundedWindows records the given windows as funded in the state file.
+// The transactions funding them have already been posted to YNAB, so a failure to save is reported rather than panicking.
+func recordFundedWindows(ctx context.Context, clock civil.Clock, appConfig *config.Config, fundingState *state.State, windows []state.Window) {
+	fundingState.RecordFunded(clock.Now(), windows...)
+
+	stateFile := resolveConfigRelativePath(appConfig.GetStateFile())
+	if err := fundingState.Save(stateFile); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to record the funded windows; they will be treated as missed by --catch-up",
+			"state_file", stateFile,
+			"error", err)
+		return
+	}
+
+	logging.FromContext(ctx).DebugContext(ctx, "recorded funded windows",
+		"state_file", stateFile,
+		"windows", len(windows))
+}
+
+// unrecordFundedWindows removes the record of the windows funded within the given dates, so that they are caught up on by --catch-up.
+func unrecordFundedWindows(ctx context.Context, appConfig *config.Config, startDate, endDate civil.Date) {
+	fundingState := loadState(appConfig)
+
+	if fundingState.RemoveFunded(startDate, endDate) == 0 {
+		return
+	}
+
+	stateFile := resolveConfigRelativePath(appConfig.GetStateFile())
+	if err := fundingState.Save(stateFile); err != nil {
+		logging.FromContext(ctx).ErrorContext(ctx, "Failed to remove the reverted windows from the record of funded windows",
+			"state_file", stateFile,
+			"error", err)
+	}
+}
+
+// isCatchUp returns true if --catch-up was supplied, requesting that windows missed since the last funded window be funded, too.
+func isCatchUp() bool {
+	for _, arg := range os.Args {
+		if arg == "--catch-up" {
+			return true
+		}
+	}
+
+	return false
+}
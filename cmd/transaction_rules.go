@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// buildTransactionRules converts the transaction rules of the offramp accounts into the rules evaluated by the math package.
+// The given categories are only consulted if a rule matches on category group.
+func buildTransactionRules(appConfig *config.Config, accountNamesByID map[string]string, categoryGroups []cliynab.CategoryGroup) *math.TransactionRules {
+	transactionRules := &math.TransactionRules{
+		RulesByAccountID: make(map[string][]*math.TransactionRule),
+	}
+
+	usesCategoryGroups := false
+	for _, offrampAccount := range appConfig.YNABAccounts.OfframpAccounts {
+		if len(offrampAccount.TransactionRules) == 0 {
+			continue
+		}
+
+		accountID := resolveSingleAccountID(accountNamesByID, offrampAccount.Name, "offramp")
+
+		for ruleIndex, ruleConfig := range offrampAccount.TransactionRules {
+			rule, err := buildTransactionRule(ruleIndex, ruleConfig)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to resolve transaction rule %d for account '%s': %v", ruleIndex, offrampAccount.Name, err))
+			}
+
+			usesCategoryGroups = usesCategoryGroups || rule.CategoryGroup != nil
+			transactionRules.RulesByAccountID[accountID] = append(transactionRules.RulesByAccountID[accountID], rule)
+		}
+	}
+
+	if usesCategoryGroups {
+		transactionRules.CategoryGroupNamesByCategoryID = make(map[string]string)
+		for _, categoryGroup := range categoryGroups {
+			for _, category := range categoryGroup.Categories {
+				transactionRules.CategoryGroupNamesByCategoryID[category.Id] = categoryGroup.Name
+			}
+		}
+	}
+
+	return transactionRules
+}
+
+func buildTransactionRule(ruleIndex int, ruleConfig *config.TransactionRule) (*math.TransactionRule, error) {
+	description := ruleConfig.Name
+	if description == "" {
+		description = fmt.Sprintf("transaction rule %d", ruleIndex)
+	}
+
+	rule := &math.TransactionRule{
+		Description:   description,
+		Exclude:       ruleConfig.Action == config.TransactionRuleActionExclude,
+		Payee:         ruleConfig.Payee,
+		Category:      ruleConfig.Category,
+		CategoryGroup: ruleConfig.CategoryGroup,
+		MemoContains:  ruleConfig.MemoContains,
+		Transfer:      ruleConfig.Transfer,
+	}
+
+	if ruleConfig.PayeePattern != nil {
+		payeePattern, err := regexp.Compile(*ruleConfig.PayeePattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile payee pattern: %w", err)
+		}
+		rule.PayeePattern = payeePattern
+	}
+
+	minimumAmountCents, hasMinimumAmount, err := ruleConfig.MinimumAmountAsCents()
+	if err != nil {
+		return nil, err
+	}
+	if hasMinimumAmount {
+		rule.MinimumAmountCents = &minimumAmountCents
+	}
+
+	maximumAmountCents, hasMaximumAmount, err := ruleConfig.MaximumAmountAsCents()
+	if err != nil {
+		return nil, err
+	}
+	if hasMaximumAmount {
+		rule.MaximumAmountCents = &maximumAmountCents
+	}
+
+	return rule, nil
+}
+
+// displayExclusions prints each transaction excluded from the outbound balances and the flag color or rule that excluded it.
+func displayExclusions(exclusions []*math.TransactionExclusion, accountNamesByID map[string]string) {
+	if len(exclusions) == 0 {
+		fmt.Println("No transactions were excluded from the outbound balances")
+		return
+	}
+
+	slices.SortStableFunc(exclusions, func(a, b *math.TransactionExclusion) int {
+		if a.Transaction.DateNext < b.Transaction.DateNext {
+			return -1
+		} else if a.Transaction.DateNext > b.Transaction.DateNext {
+			return 1
+		}
+
+		return 0
+	})
+
+	fmt.Println("Transactions excluded from the outbound balances:")
+	for _, exclusion := range exclusions {
+		fmt.Printf("  %s %s: %s to %s (%s)\n",
+			exclusion.Transaction.DateNext,
+			accountNamesByID[exclusion.Transaction.AccountId],
+			currency.FormatCents(currency.MilliunitsToCents(exclusion.Transaction.Amount)),
+			exclusion.Transaction.PayeeName,
+			exclusion.Reason)
+	}
+}
+
+// isExplain returns true if --explain was supplied, requesting that the transactions excluded from the calculations be listed.
+func isExplain() bool {
+	for _, arg := range os.Args {
+		if arg == "--explain" {
+			return true
+		}
+	}
+
+	return false
+}
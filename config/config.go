@@ -3,8 +3,10 @@ package config
 import (
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
 )
 
 type Config struct {
@@ -15,6 +17,124 @@ type Config struct {
 	RecipientAddress string              `yaml:"recipient_address"`
 	YNABBudgetName   string              `yaml:"ynab_budget_name"`
 	YNABAccounts     *YNABAccountsConfig `yaml:"ynab_accounts"`
+	Settlement       *SettlementConfig   `yaml:"settlement"`
+	Calendar         *CalendarConfig     `yaml:"calendar"`
+	StateFile        *string             `yaml:"state_file"`   // If specified, the path of the file recording which windows have been funded
+	HistoryFile      *string             `yaml:"history_file"` // If specified, the path of the file recording each run whose transactions were created in YNAB
+	CacheFile        *string             `yaml:"cache_file"`   // If specified, the path of the file caching budget data between runs
+}
+
+// defaultStateFile is the file, relative to the configuration file, that records which windows have been funded if no other file is specified.
+const defaultStateFile = "offramp-state.json"
+
+// defaultHistoryFile is the file, relative to the configuration file, that records each run if no other file is specified.
+const defaultHistoryFile = "offramp-history.jsonl"
+
+// defaultCacheFile is the file, relative to the configuration file, that caches budget data between runs if no other file is specified.
+const defaultCacheFile = "offramp-cache.json"
+
+// GetCacheFile returns the path of the file caching budget data between runs, defaulting to offramp-cache.json.
+func (c *Config) GetCacheFile() string {
+	if c.CacheFile == nil {
+		return defaultCacheFile
+	}
+
+	return *c.CacheFile
+}
+
+// GetHistoryFile returns the path of the file recording each run whose transactions were created in YNAB, defaulting to offramp-history.jsonl.
+func (c *Config) GetHistoryFile() string {
+	if c.HistoryFile == nil {
+		return defaultHistoryFile
+	}
+
+	return *c.HistoryFile
+}
+
+// GetStateFile returns the path of the file recording which windows have been funded, defaulting to offramp-state.json.
+func (c *Config) GetStateFile() string {
+	if c.StateFile == nil {
+		return defaultStateFile
+	}
+
+	return *c.StateFile
+}
+
+// SettlementConfig describes how long funds sent through the offramp take to arrive in the offramp accounts.
+type SettlementConfig struct {
+	DelayBusinessDays int `yaml:"delay_business_days"` // The number of business days after funds are sent that they arrive
+}
+
+// GetSettlementDelayBusinessDays returns the number of business days funds take to arrive, defaulting to 0 if no settlement is configured.
+func (c *Config) GetSettlementDelayBusinessDays() int {
+	if c.Settlement == nil {
+		return 0
+	}
+
+	return c.Settlement.DelayBusinessDays
+}
+
+// CalendarConfig describes which days are business days and the window of dates that is funded by default.
+type CalendarConfig struct {
+	Holidays                *string       `yaml:"holidays"`                    // If specified, the path to an ICS, YAML, or text file listing the holidays that are not business days
+	ShiftBillsToBusinessDay bool          `yaml:"shift_bills_to_business_day"` // If true, bills scheduled on a weekend or holiday are counted on the business day before it
+	Window                  *WindowConfig `yaml:"window"`                      // If specified, the cadence to which the default window of dates is aligned
+}
+
+// WindowConfig describes the cadence to which the default window of dates is aligned.
+type WindowConfig struct {
+	Cadence        string  `yaml:"cadence"`          // Whether windows are made of weeks or pay periods
+	WeekStart      *string `yaml:"week_start"`       // If specified, the day on which weeks start; defaults to Monday
+	PayPeriodStart *string `yaml:"pay_period_start"` // Any date, as YYYY-MM-DD, on which a pay period starts; required for pay period windows
+	PayPeriodDays  *int    `yaml:"pay_period_days"`  // If specified, the number of days in each pay period; defaults to 14
+	Periods        *int    `yaml:"periods"`          // If specified, the number of weeks or pay periods in each window; defaults to 1
+}
+
+// ShiftsBillsToBusinessDay returns true if bills scheduled on a weekend or holiday are to be counted on the business day before it.
+func (c *Config) ShiftsBillsToBusinessDay() bool {
+	return c.Calendar != nil && c.Calendar.ShiftBillsToBusinessDay
+}
+
+// GetWeekStart returns the day on which weeks start, defaulting to Monday if none is specified.
+func (w *WindowConfig) GetWeekStart() string {
+	if w.WeekStart == nil {
+		return time.Monday.String()
+	}
+
+	return *w.WeekStart
+}
+
+// PayPeriodStartDate returns the date on which a pay period starts.
+// If there is no pay period start specified, the returned boolean is false; otherwise, it is true.
+func (w *WindowConfig) PayPeriodStartDate() (civil.Date, bool, error) {
+	if w.PayPeriodStart == nil {
+		return civil.Date{}, false, nil
+	}
+
+	payPeriodStart, err := civil.ParseDate(*w.PayPeriodStart)
+	if err != nil {
+		return civil.Date{}, false, fmt.Errorf("failed to parse pay period start: %w", err)
+	}
+
+	return payPeriodStart, true, nil
+}
+
+// GetPayPeriodDays returns the number of days in each pay period, defaulting to 14 if none is specified.
+func (w *WindowConfig) GetPayPeriodDays() int {
+	if w.PayPeriodDays == nil {
+		return defaultPayPeriodDays
+	}
+
+	return *w.PayPeriodDays
+}
+
+// GetPeriods returns the number of weeks or pay periods in each window, defaulting to 1 if none is specified.
+func (w *WindowConfig) GetPeriods() int {
+	if w.Periods == nil {
+		return 1
+	}
+
+	return *w.Periods
 }
 
 type YNABAccountsConfig struct {
@@ -25,49 +145,204 @@ type YNABAccountsConfig struct {
 
 func (c *Config) GetQRCodeType() string {
 	if c.QRCodeType == nil {
-		return "erc681"
+		return QRCodeTypeERC681
 	}
 
 	return *c.QRCodeType
 }
 
+const (
+	// WindowCadenceWeekly aligns the default window to whole weeks.
+	WindowCadenceWeekly = "weekly"
+	// WindowCadencePayPeriod aligns the default window to whole pay periods of a fixed number of days.
+	WindowCadencePayPeriod = "pay_period"
+
+	defaultPayPeriodDays = 14
+)
+
+const (
+	// AccountTypeCash describes an account that holds cash, such as a checking or savings account.
+	AccountTypeCash = "cash"
+	// AccountTypeCreditCard describes a credit card account, whose balance is typically negative.
+	AccountTypeCreditCard = "credit_card"
+)
+
+const (
+	// FundingModeGross funds the sum of all outbound transactions within the window.
+	FundingModeGross = "gross"
+	// FundingModeNet funds only the shortfall needed to keep the account's projected day-by-day balance above its minimum.
+	FundingModeNet = "net"
+)
+
+const (
+	// FundingSourceScheduled funds the account with its scheduled (and entered) transactions within the window.
+	FundingSourceScheduled = "scheduled"
+	// FundingSourceCategory funds the account with the amount available in the month's budget for its funding categories.
+	FundingSourceCategory = "category"
+)
+
+const (
+	// BalanceSourceBalance starts calculations from the account's balance, including uncleared and future-dated transactions.
+	BalanceSourceBalance = "balance"
+	// BalanceSourceClearedBalance starts calculations from the account's cleared balance.
+	BalanceSourceClearedBalance = "cleared_balance"
+	// BalanceSourceWorkingBalance starts calculations from the account's balance as of today, excluding future-dated transactions.
+	BalanceSourceWorkingBalance = "working_balance"
+)
+
 type YNABOfframpAccountConfig struct {
-	Name               string       `yaml:"name"`                 // The name of the account as it appears in YNAB
-	ExcludedFlagColors []string     `yaml:"excluded_flag_colors"` // If specified, this is a list of flag colors to exclude from calculations
-	MinimumBalance     *json.Number `yaml:"minimum_balance"`      // If specified, this is the minimum balance to be maintained between now and the given end billing date
+	Name                   string                 `yaml:"name"`                     // The name of the account as it appears in YNAB
+	Type                   *string                `yaml:"type"`                     // If specified, the type of the account; defaults to cash
+	FundingMode            *string                `yaml:"funding_mode"`             // If specified, how the funding for the account is calculated; defaults to gross
+	FundingSource          *string                `yaml:"funding_source"`           // If specified, what determines the funding of the account; defaults to scheduled
+	FundingCategories      []string               `yaml:"funding_categories"`       // The names of the categories whose available amounts fund the account when the funding source is category
+	BalanceSource          *string                `yaml:"balance_source"`           // If specified, which of the account's balances calculations start from; defaults to balance
+	ExcludedFlagColors     []string               `yaml:"excluded_flag_colors"`     // If specified, this is a list of flag colors to exclude from calculations
+	IncludedFlagColors     []string               `yaml:"included_flag_colors"`     // If specified, only transactions with one of these flag colors are included in calculations
+	MinimumBalance         *json.Number           `yaml:"minimum_balance"`          // If specified, this is the minimum balance to be maintained between now and the given end billing date
+	MinimumBalanceRounding currency.RoundingMode  `yaml:"minimum_balance_rounding"` // If specified, how a minimum balance with fractional cents is to be rounded; otherwise, fractional cents are rejected
+	MinimumBalanceTargets  *MinimumBalanceTargets `yaml:"minimum_balance_targets"`  // If specified, rules that determine a minimum balance alongside (or instead of) minimum_balance
+	MaximumBalance         *json.Number           `yaml:"maximum_balance"`          // If specified, any balance projected above this after the given end billing date is to be swept out of the account
+	SweepAccount           *string                `yaml:"sweep_account"`            // If specified, the name of the account to which excess balance is swept; defaults to the funds recipient account
+	TransactionRules       []*TransactionRule     `yaml:"transaction_rules"`        // If specified, rules that include or exclude transactions from the calculations, evaluated in order
 }
 
-// MinimumBalanceAsCents returns the minimum balance as cents.
-// If there is no minimum balance specified, the returned boolean is false; otherwise, it is true.
-func (y *YNABOfframpAccountConfig) MinimumBalanceAsCents() (int, bool, error) {
-	if y.MinimumBalance == nil {
+const (
+	// TransactionRuleActionInclude counts the transactions matched by a rule.
+	TransactionRuleActionInclude = "include"
+	// TransactionRuleActionExclude omits the transactions matched by a rule from calculations.
+	TransactionRuleActionExclude = "exclude"
+)
+
+// TransactionRule describes transactions that are to be included in or excluded from calculations.
+// A transaction matches a rule if it meets all of the rule's conditions; a rule with no conditions matches every transaction.
+type TransactionRule struct {
+	Name          string       `yaml:"name"`           // If specified, how the rule is described when explaining which rule excluded a transaction
+	Action        string       `yaml:"action"`         // Whether matched transactions are included or excluded
+	Payee         *string      `yaml:"payee"`          // If specified, the name of the payee, matched without regard to case
+	PayeePattern  *string      `yaml:"payee_pattern"`  // If specified, a regular expression that the payee name must match
+	Category      *string      `yaml:"category"`       // If specified, the name of the category, matched without regard to case
+	CategoryGroup *string      `yaml:"category_group"` // If specified, the name of the category group, matched without regard to case
+	MemoContains  *string      `yaml:"memo_contains"`  // If specified, text that the memo must contain, matched without regard to case
+	MinimumAmount *json.Number `yaml:"minimum_amount"` // If specified, the smallest amount (regardless of sign) of a matched transaction
+	MaximumAmount *json.Number `yaml:"maximum_amount"` // If specified, the largest amount (regardless of sign) of a matched transaction
+	Transfer      *bool        `yaml:"transfer"`       // If specified, whether matched transactions must (true) or must not (false) be transfers
+}
+
+// MinimumAmountAsCents returns the minimum amount of the rule as cents.
+// If there is no minimum amount specified, the returned boolean is false; otherwise, it is true.
+func (t *TransactionRule) MinimumAmountAsCents() (int, bool, error) {
+	if t.MinimumAmount == nil {
+		return 0, false, nil
+	}
+
+	minimumAmountCents, err := currency.ParseCents(t.MinimumAmount.String(), currency.RoundingNone)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse minimum amount: %w", err)
+	}
+
+	return minimumAmountCents, true, nil
+}
+
+// MaximumAmountAsCents returns the maximum amount of the rule as cents.
+// If there is no maximum amount specified, the returned boolean is false; otherwise, it is true.
+func (t *TransactionRule) MaximumAmountAsCents() (int, bool, error) {
+	if t.MaximumAmount == nil {
 		return 0, false, nil
 	}
 
-	minimumBalanceString := y.MinimumBalance.String()
+	maximumAmountCents, err := currency.ParseCents(t.MaximumAmount.String(), currency.RoundingNone)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse maximum amount: %w", err)
+	}
+
+	return maximumAmountCents, true, nil
+}
 
-	var parsedDollars int
-	var parsedCents int
+// MinimumBalanceTargets describes rules that determine a minimum balance to be maintained from the upcoming transactions.
+// When more than one rule (including a fixed minimum balance) is given, the highest resulting minimum balance is used.
+type MinimumBalanceTargets struct {
+	OutflowPercentage     *float64 `yaml:"outflow_percentage"`       // If specified, maintain this percentage of the window's projected outflow as a buffer
+	LargestBill           bool     `yaml:"largest_bill"`             // If true, maintain a balance equal to the largest single bill within the window
+	CoverDaysBeyondWindow int      `yaml:"cover_days_beyond_window"` // If positive, maintain a balance that covers the bills in this many days after the window
+}
 
-	periodPos := strings.Index(minimumBalanceString, ".")
-	if periodPos == -1 {
-		parsedDollarsInt64, err := strconv.Atoi(minimumBalanceString)
-		if err != nil {
-			return 0, false, fmt.Errorf("failed to parse minimum balance '%s' as an integer: %w", minimumBalanceString, err)
-		}
+// HasMinimumBalanceRules returns true if a fixed minimum balance or any minimum balance targets are configured.
+func (y *YNABOfframpAccountConfig) HasMinimumBalanceRules() bool {
+	if y.MinimumBalance != nil {
+		return true
+	}
 
-		return parsedDollarsInt64 * 100, true, nil
+	targets := y.MinimumBalanceTargets
+	if targets == nil {
+		return false
 	}
 
-	parsedDollars, err := strconv.Atoi(minimumBalanceString[:periodPos])
+	return targets.OutflowPercentage != nil || targets.LargestBill || targets.CoverDaysBeyondWindow > 0
+}
+
+// GetType returns the type of the account, defaulting to AccountTypeCash if none is specified.
+func (y *YNABOfframpAccountConfig) GetType() string {
+	if y.Type == nil {
+		return AccountTypeCash
+	}
+
+	return *y.Type
+}
+
+// GetBalanceSource returns the balance from which calculations for the account start, defaulting to BalanceSourceBalance if none is specified.
+func (y *YNABOfframpAccountConfig) GetBalanceSource() string {
+	if y.BalanceSource == nil {
+		return BalanceSourceBalance
+	}
+
+	return *y.BalanceSource
+}
+
+// GetFundingSource returns what determines the funding of the account, defaulting to FundingSourceScheduled if none is specified.
+func (y *YNABOfframpAccountConfig) GetFundingSource() string {
+	if y.FundingSource == nil {
+		return FundingSourceScheduled
+	}
+
+	return *y.FundingSource
+}
+
+// GetFundingMode returns the funding mode of the account, defaulting to FundingModeGross if none is specified.
+func (y *YNABOfframpAccountConfig) GetFundingMode() string {
+	if y.FundingMode == nil {
+		return FundingModeGross
+	}
+
+	return *y.FundingMode
+}
+
+// MinimumBalanceAsCents returns the minimum balance as cents.
+// If there is no minimum balance specified, the returned boolean is false; otherwise, it is true.
+func (y *YNABOfframpAccountConfig) MinimumBalanceAsCents() (int, bool, error) {
+	if y.MinimumBalance == nil {
+		return 0, false, nil
+	}
+
+	minimumBalanceCents, err := currency.ParseCents(y.MinimumBalance.String(), y.MinimumBalanceRounding)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to parse minimum balance '%s' as an integer: %w", minimumBalanceString, err)
+		return 0, false, fmt.Errorf("failed to parse minimum balance: %w", err)
+	}
+
+	return minimumBalanceCents, true, nil
+}
+
+// MaximumBalanceAsCents returns the maximum balance as cents.
+// If there is no maximum balance specified, the returned boolean is false; otherwise, it is true.
+func (y *YNABOfframpAccountConfig) MaximumBalanceAsCents() (int, bool, error) {
+	if y.MaximumBalance == nil {
+		return 0, false, nil
 	}
 
-	parsedCents, err = strconv.Atoi(minimumBalanceString[periodPos+1:])
+	maximumBalanceCents, err := currency.ParseCents(y.MaximumBalance.String(), currency.RoundingNone)
 	if err != nil {
-		return 0, false, fmt.Errorf("failed to parse minimum balance '%s' as an integer: %w", minimumBalanceString, err)
+		return 0, false, fmt.Errorf("failed to parse maximum balance: %w", err)
 	}
 
-	return parsedDollars*100 + parsedCents, true, nil
+	return maximumBalanceCents, true, nil
 }
@@ -0,0 +1,87 @@
+package config_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+var _ = Describe("Config", func() {
+	Context("MinimumBalanceAsCents", func() {
+		When("there is no minimum balance", func() {
+			It("reports that there is no minimum balance", func() {
+				accountConfig := &config.YNABOfframpAccountConfig{}
+
+				_, hasMinimumBalance, err := accountConfig.MinimumBalanceAsCents()
+				Expect(err).ToNot(HaveOccurred(), "resolving a missing minimum balance should not fail")
+				Expect(hasMinimumBalance).To(BeFalse(), "there should be no minimum balance")
+			})
+		})
+
+		DescribeTable("valid minimum balances",
+			func(minimumBalance string, rounding currency.RoundingMode, expectedCents int) {
+				number := json.Number(minimumBalance)
+				accountConfig := &config.YNABOfframpAccountConfig{
+					MinimumBalance:         &number,
+					MinimumBalanceRounding: rounding,
+				}
+
+				cents, hasMinimumBalance, err := accountConfig.MinimumBalanceAsCents()
+				Expect(err).ToNot(HaveOccurred(), "parsing the minimum balance should not fail")
+				Expect(hasMinimumBalance).To(BeTrue(), "there should be a minimum balance")
+				Expect(cents).To(Equal(expectedCents), "the minimum balance should be parsed to the correct number of cents")
+			},
+			Entry("whole dollars", "10", currency.RoundingNone, 1000),
+			Entry("a single fractional digit", "10.5", currency.RoundingNone, 1050),
+			Entry("two fractional digits", "10.05", currency.RoundingNone, 1005),
+			Entry("only cents", ".75", currency.RoundingNone, 75),
+			Entry("a negative amount", "-5.25", currency.RoundingNone, -525),
+			Entry("a negative amount of less than a dollar", "-0.5", currency.RoundingNone, -50),
+			Entry("a dollar sign", "$12.34", currency.RoundingNone, 1234),
+			Entry("insignificant trailing zeroes", "10.5000", currency.RoundingNone, 1050),
+			Entry("rounding half up below the midpoint", "10.504", currency.RoundingHalfUp, 1050),
+			Entry("rounding half up at the midpoint", "10.505", currency.RoundingHalfUp, 1051),
+			Entry("rounding half up a negative amount", "-10.505", currency.RoundingHalfUp, -1051),
+			Entry("rounding down", "10.509", currency.RoundingDown, 1050),
+			Entry("rounding up", "10.501", currency.RoundingUp, 1051),
+		)
+
+		DescribeTable("invalid minimum balances",
+			func(minimumBalance string) {
+				number := json.Number(minimumBalance)
+				accountConfig := &config.YNABOfframpAccountConfig{
+					MinimumBalance: &number,
+				}
+
+				_, _, err := accountConfig.MinimumBalanceAsCents()
+				Expect(err).To(HaveOccurred(), "parsing the minimum balance should fail")
+			},
+			Entry("excess precision without rounding", "10.505"),
+			Entry("a non-numeric value", "ten"),
+			Entry("an exponent", "1e3"),
+			Entry("a lone decimal point", "."),
+			Entry("multiple decimal points", "1.2.3"),
+		)
+
+		It("accepts both YAML numbers and strings", func() {
+			document := validConfiguration + `    - name: "Quoted"
+      minimum_balance: "20.10"
+`
+
+			parsed, err := config.Parse([]byte(document))
+			Expect(err).ToNot(HaveOccurred(), "parsing the configuration should not fail")
+
+			numericCents, _, err := parsed.YNABAccounts.OfframpAccounts[0].MinimumBalanceAsCents()
+			Expect(err).ToNot(HaveOccurred(), "parsing the numeric minimum balance should not fail")
+			Expect(numericCents).To(Equal(10025), "the numeric minimum balance should be parsed")
+
+			quotedCents, _, err := parsed.YNABAccounts.OfframpAccounts[2].MinimumBalanceAsCents()
+			Expect(err).ToNot(HaveOccurred(), "parsing the quoted minimum balance should not fail")
+			Expect(quotedCents).To(Equal(2010), "the quoted minimum balance should be parsed")
+		})
+	})
+})
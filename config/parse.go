@@ -0,0 +1,100 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Parse decodes the given YAML document into a configuration and validates it.
+// Keys that do not correspond to a known configuration field are rejected.
+// If the configuration fails validation, a ValidationErrors is returned
+// whose entries carry the line and column of the offending field within the document.
+func Parse(document []byte) (*Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(document))
+	decoder.KnownFields(true)
+
+	parsed := &Config{}
+	if err := decoder.Decode(parsed); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the configuration is empty")
+		}
+
+		return nil, fmt.Errorf("failed to decode configuration: %w", err)
+	}
+
+	validationErr := parsed.Validate()
+	if validationErr == nil {
+		return parsed, nil
+	}
+
+	var validationErrs ValidationErrors
+	if !errors.As(validationErr, &validationErrs) {
+		return nil, validationErr
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(document, &root); err != nil {
+		return nil, fmt.Errorf("failed to decode configuration for diagnostics: %w", err)
+	}
+
+	for _, err := range validationErrs {
+		if node := findNode(&root, err.path); node != nil {
+			err.Line = node.Line
+			err.Column = node.Column
+		}
+	}
+
+	return nil, validationErrs
+}
+
+// findNode finds the node at the given path within the given document.
+// If the full path does not exist, the deepest node along the path that does exist is returned.
+func findNode(root *yaml.Node, path []any) *yaml.Node {
+	current := root
+	if current.Kind == yaml.DocumentNode {
+		if len(current.Content) == 0 {
+			return nil
+		}
+
+		current = current.Content[0]
+	}
+
+	for _, segment := range path {
+		next := childNode(current, segment)
+		if next == nil {
+			break
+		}
+
+		current = next
+	}
+
+	return current
+}
+
+func childNode(parent *yaml.Node, segment any) *yaml.Node {
+	switch typedSegment := segment.(type) {
+	case int:
+		if parent.Kind != yaml.SequenceNode || typedSegment < 0 || typedSegment >= len(parent.Content) {
+			return nil
+		}
+
+		return parent.Content[typedSegment]
+	case string:
+		if parent.Kind != yaml.MappingNode {
+			return nil
+		}
+
+		// mapping nodes alternate between key and value nodes
+		for contentIndex := 0; contentIndex+1 < len(parent.Content); contentIndex += 2 {
+			if parent.Content[contentIndex].Value == typedSegment {
+				return parent.Content[contentIndex+1]
+			}
+		}
+	}
+
+	return nil
+}
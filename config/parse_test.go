@@ -0,0 +1,115 @@
+package config_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+)
+
+const validConfiguration = `recipient_address: "0x407DF19995bBA21E71EC6e6b72FEba70318031Be"
+contract_address: "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+decimals: 6
+chain_id: 8453
+ynab_budget_name: "Budget"
+ynab_accounts:
+  funds_origin_account: "Wallet"
+  funds_recipient_account: "Offramp"
+  offramp_accounts:
+    - name: "Checking"
+      minimum_balance: 100.25
+      excluded_flag_colors:
+        - green
+    - name: "Savings"
+`
+
+var _ = Describe("Parse", func() {
+	It("parses a valid configuration", func() {
+		parsed, err := config.Parse([]byte(validConfiguration))
+		Expect(err).ToNot(HaveOccurred(), "parsing a valid configuration should not fail")
+		Expect(parsed.ChainID).To(Equal(8453), "the chain ID should be parsed")
+		Expect(parsed.GetQRCodeType()).To(Equal(config.QRCodeTypeERC681), "the QR code type should default to ERC-681")
+		Expect(parsed.YNABAccounts.OfframpAccounts).To(HaveLen(2), "both offramp accounts should be parsed")
+	})
+
+	When("the configuration contains an unknown key", func() {
+		It("rejects the configuration", func() {
+			_, err := config.Parse([]byte(validConfiguration + "unknown_key: true\n"))
+			Expect(err).To(HaveOccurred(), "an unknown key should fail parsing")
+			Expect(err.Error()).To(And(ContainSubstring("unknown_key"), ContainSubstring("line 15")), "the unknown key and its location should be reported")
+		})
+	})
+
+	When("the configuration is empty", func() {
+		It("returns an error", func() {
+			_, err := config.Parse([]byte(""))
+			Expect(err).To(HaveOccurred(), "an empty configuration should fail")
+		})
+	})
+
+	When("the configuration is invalid", func() {
+		It("reports the line and column of the problem", func() {
+			document := `recipient_address: "0x407DF19995bBA21E71EC6e6b72FEba70318031Be"
+contract_address: "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+decimals: 6
+chain_id: 8453
+qr_code_type: "bogus"
+ynab_budget_name: "Budget"
+ynab_accounts:
+  funds_origin_account: "Wallet"
+  funds_recipient_account: "Offramp"
+  offramp_accounts:
+    - name: "Checking"
+      excluded_flag_colors:
+        - gren
+`
+
+			_, err := config.Parse([]byte(document))
+			Expect(err).To(HaveOccurred(), "an invalid configuration should fail")
+
+			var validationErrs config.ValidationErrors
+			Expect(errors.As(err, &validationErrs)).To(BeTrue(), "the error should describe the validation failures")
+			Expect(validationErrs).To(HaveLen(2), "both problems should be reported")
+
+			qrCodeTypeErr := findValidationError(validationErrs, "qr_code_type")
+			Expect(qrCodeTypeErr.Line).To(Equal(5), "the QR code type problem should be reported on its line")
+			Expect(qrCodeTypeErr.Column).To(Equal(15), "the QR code type problem should be reported at its value")
+
+			flagColorErr := findValidationError(validationErrs, "ynab_accounts.offramp_accounts[0].excluded_flag_colors[0]")
+			Expect(flagColorErr.Line).To(Equal(13), "the flag color problem should be reported on its line")
+			Expect(flagColorErr.Message).To(ContainSubstring("gren"), "the unknown flag color should be named")
+		})
+
+		When("a required section is missing", func() {
+			It("reports the problem instead of panicking", func() {
+				document := `recipient_address: "0x407DF19995bBA21E71EC6e6b72FEba70318031Be"
+contract_address: "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"
+decimals: 6
+chain_id: 8453
+ynab_budget_name: "Budget"
+`
+
+				_, err := config.Parse([]byte(document))
+				Expect(err).To(HaveOccurred(), "a missing required section should fail")
+
+				var validationErrs config.ValidationErrors
+				Expect(errors.As(err, &validationErrs)).To(BeTrue(), "the error should describe the validation failures")
+				Expect(findValidationError(validationErrs, "ynab_accounts").Line).To(Equal(1), "a missing field should be reported against its parent")
+			})
+		})
+	})
+})
+
+func findValidationError(validationErrs config.ValidationErrors, field string) *config.ValidationError {
+	for _, validationErr := range validationErrs {
+		if validationErr.Field == field {
+			return validationErr
+		}
+	}
+
+	Fail("no validation error found for field " + field)
+
+	return nil
+}
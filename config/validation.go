@@ -0,0 +1,396 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+const (
+	// QRCodeTypeERC681 generates an ERC-681-compliant QR code.
+	QRCodeTypeERC681 = "erc681"
+	// QRCodeTypeRecipientOnly generates a QR code that only contains the recipient address.
+	QRCodeTypeRecipientOnly = "recipient_only"
+
+	minimumDecimals = 2
+	maximumDecimals = 18
+)
+
+// FlagColorUnflagged matches transactions without a flag color.
+const FlagColorUnflagged = "unflagged"
+
+// FlagColors are the flag colors supported by YNAB, along with FlagColorUnflagged.
+var FlagColors = []string{"red", "orange", "yellow", "green", "blue", "purple", FlagColorUnflagged}
+
+var contractAddressPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+// ValidationError describes a single problem found within the configuration.
+type ValidationError struct {
+	Field   string // the path to the offending field, e.g. ynab_accounts.offramp_accounts[0].name
+	Message string // a description of the problem
+	Line    int    // the line within the YAML document at which the problem was found; 0 if not known
+	Column  int    // the column within the YAML document at which the problem was found; 0 if not known
+
+	path []any // the segments of the field path; strings are mapping keys and ints are sequence indexes
+}
+
+func (v *ValidationError) Error() string {
+	if v.Line > 0 {
+		return fmt.Sprintf("line %d, column %d: %s: %s", v.Line, v.Column, v.Field, v.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", v.Field, v.Message)
+}
+
+// ValidationErrors is a collection of all of the problems found within a configuration.
+type ValidationErrors []*ValidationError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for errIndex, validationErr := range v {
+		messages[errIndex] = "  - " + validationErr.Error()
+	}
+
+	return fmt.Sprintf("%d configuration problem(s) found:\n%s", len(v), strings.Join(messages, "\n"))
+}
+
+// Validate checks the configuration for missing or invalid values.
+// If any problems are found, a ValidationErrors describing all of them is returned.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, path...))
+	}
+
+	// chain IDs are not checked against a list of chains, as ERC-681 QR codes can name any EVM chain
+	if c.ChainID <= 0 {
+		addError("must be a positive number", "chain_id")
+	}
+
+	if c.Decimals < minimumDecimals || c.Decimals > maximumDecimals {
+		addError(fmt.Sprintf("must be between %d and %d (inclusive), but was %d", minimumDecimals, maximumDecimals, c.Decimals), "decimals")
+	}
+
+	if c.RecipientAddress == "" {
+		addError("is required", "recipient_address")
+	}
+
+	switch qrCodeType := c.GetQRCodeType(); qrCodeType {
+	case QRCodeTypeERC681:
+		if !contractAddressPattern.MatchString(c.ContractAddress) {
+			addError(fmt.Sprintf("must be a 0x-prefixed, 40-character hexadecimal address when generating %s QR codes", QRCodeTypeERC681), "contract_address")
+		}
+	case QRCodeTypeRecipientOnly:
+		// nothing else is needed
+	default:
+		addError(fmt.Sprintf("unsupported QR code type '%s'; must be one of '%s' or '%s'", qrCodeType, QRCodeTypeERC681, QRCodeTypeRecipientOnly), "qr_code_type")
+	}
+
+	if c.Settlement != nil && c.Settlement.DelayBusinessDays < 0 {
+		addError("must not be negative", "settlement", "delay_business_days")
+	}
+
+	if c.Calendar != nil && c.Calendar.Window != nil {
+		errs = append(errs, c.Calendar.Window.validate()...)
+	}
+
+	if c.YNABBudgetName == "" {
+		addError("is required", "ynab_budget_name")
+	}
+
+	if c.YNABAccounts == nil {
+		addError("is required", "ynab_accounts")
+	} else {
+		errs = append(errs, c.YNABAccounts.validate()...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func (y *YNABAccountsConfig) validate() ValidationErrors {
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, append([]any{"ynab_accounts"}, path...)...))
+	}
+
+	if y.FundsOriginAccount == "" {
+		addError("is required", "funds_origin_account")
+	}
+
+	if y.FundsRecipientAccount == "" {
+		addError("is required", "funds_recipient_account")
+	}
+
+	if len(y.OfframpAccounts) == 0 {
+		addError("at least one offramp account is required", "offramp_accounts")
+	}
+
+	for accountIndex, offrampAccount := range y.OfframpAccounts {
+		if offrampAccount == nil {
+			addError("must not be empty", "offramp_accounts", accountIndex)
+			continue
+		}
+
+		errs = append(errs, offrampAccount.validate(accountIndex)...)
+	}
+
+	errs = append(errs, y.validateAccountNames()...)
+
+	return errs
+}
+
+// validateAccountNames reports each account whose name is already used by another account, whatever its kind:
+// accounts are resolved by name, so two accounts with the same name cannot be told apart.
+func (y *YNABAccountsConfig) validateAccountNames() ValidationErrors {
+	var errs ValidationErrors
+	accountDescriptionsByName := make(map[string]string)
+	checkName := func(name string, description string, path ...any) {
+		if name == "" {
+			return
+		}
+
+		if firstDescription, isDuplicate := accountDescriptionsByName[name]; isDuplicate {
+			errs = append(errs, newValidationError(fmt.Sprintf("duplicates the name of %s ('%s')", firstDescription, name), append([]any{"ynab_accounts"}, path...)...))
+			return
+		}
+
+		accountDescriptionsByName[name] = description
+	}
+
+	checkName(y.FundsOriginAccount, "the funds origin account", "funds_origin_account")
+	checkName(y.FundsRecipientAccount, "the funds recipient account", "funds_recipient_account")
+	for accountIndex, offrampAccount := range y.OfframpAccounts {
+		if offrampAccount != nil {
+			checkName(offrampAccount.Name, fmt.Sprintf("offramp account %d", accountIndex), "offramp_accounts", accountIndex, "name")
+		}
+	}
+
+	return errs
+}
+
+func (o *YNABOfframpAccountConfig) validate(accountIndex int) ValidationErrors {
+	accountPath := []any{"ynab_accounts", "offramp_accounts", accountIndex}
+
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, append(slices.Clone(accountPath), path...)...))
+	}
+
+	if o.Name == "" {
+		addError("is required", "name")
+	}
+
+	for colorIndex, flagColor := range o.ExcludedFlagColors {
+		if !isFlagColor(flagColor) {
+			addError(fmt.Sprintf("unknown flag color '%s'; must be one of: %s", flagColor, strings.Join(FlagColors, ", ")), "excluded_flag_colors", colorIndex)
+		}
+	}
+
+	for colorIndex, flagColor := range o.IncludedFlagColors {
+		if !isFlagColor(flagColor) {
+			addError(fmt.Sprintf("unknown flag color '%s'; must be one of: %s", flagColor, strings.Join(FlagColors, ", ")), "included_flag_colors", colorIndex)
+		}
+	}
+
+	if len(o.IncludedFlagColors) > 0 && len(o.ExcludedFlagColors) > 0 {
+		addError("must not be given alongside excluded_flag_colors", "included_flag_colors")
+	}
+
+	accountType := o.GetType()
+	if accountType != AccountTypeCash && accountType != AccountTypeCreditCard {
+		addError(fmt.Sprintf("unsupported account type '%s'; must be one of '%s' or '%s'", accountType, AccountTypeCash, AccountTypeCreditCard), "type")
+	}
+
+	if fundingMode := o.GetFundingMode(); fundingMode != FundingModeGross && fundingMode != FundingModeNet {
+		addError(fmt.Sprintf("unsupported funding mode '%s'; must be one of '%s' or '%s'", fundingMode, FundingModeGross, FundingModeNet), "funding_mode")
+	} else if fundingMode == FundingModeNet && accountType == AccountTypeCreditCard {
+		addError(fmt.Sprintf("must not be '%s' for '%s' accounts, which are funded from their payment category", FundingModeNet, AccountTypeCreditCard), "funding_mode")
+	}
+
+	switch fundingSource := o.GetFundingSource(); fundingSource {
+	case FundingSourceScheduled:
+		if len(o.FundingCategories) > 0 {
+			addError(fmt.Sprintf("must only be given when the funding source is '%s'", FundingSourceCategory), "funding_categories")
+		}
+	case FundingSourceCategory:
+		if len(o.FundingCategories) == 0 {
+			addError(fmt.Sprintf("at least one category is required when the funding source is '%s'", FundingSourceCategory), "funding_categories")
+		}
+
+		if accountType == AccountTypeCreditCard {
+			addError(fmt.Sprintf("must be '%s' for '%s' accounts, which are funded from their payment category", FundingSourceScheduled, AccountTypeCreditCard), "funding_source")
+		} else if o.GetFundingMode() == FundingModeNet {
+			addError(fmt.Sprintf("must be '%s' when the funding mode is '%s'", FundingSourceScheduled, FundingModeNet), "funding_source")
+		}
+	default:
+		addError(fmt.Sprintf("unsupported funding source '%s'; must be one of '%s' or '%s'", fundingSource, FundingSourceScheduled, FundingSourceCategory), "funding_source")
+	}
+
+	switch balanceSource := o.GetBalanceSource(); balanceSource {
+	case BalanceSourceBalance, BalanceSourceClearedBalance, BalanceSourceWorkingBalance:
+	default:
+		addError(fmt.Sprintf("unsupported balance source '%s'; must be one of '%s', '%s', or '%s'", balanceSource, BalanceSourceBalance, BalanceSourceClearedBalance, BalanceSourceWorkingBalance), "balance_source")
+	}
+
+	// the maximum balance is compared against the minimum balance only if the minimum balance is itself valid
+	var minimumBalanceCents int
+	minimumBalanceValid := false
+	if !o.MinimumBalanceRounding.IsValid() {
+		addError(fmt.Sprintf("unsupported rounding mode '%s'; must be one of '%s', '%s', or '%s'", o.MinimumBalanceRounding, currency.RoundingHalfUp, currency.RoundingDown, currency.RoundingUp), "minimum_balance_rounding")
+	} else if parsedCents, _, err := o.MinimumBalanceAsCents(); err != nil {
+		addError(err.Error(), "minimum_balance")
+	} else if parsedCents < 0 && accountType != AccountTypeCreditCard {
+		addError(fmt.Sprintf("must not be negative unless the account type is '%s'", AccountTypeCreditCard), "minimum_balance")
+	} else {
+		minimumBalanceCents = parsedCents
+		minimumBalanceValid = true
+	}
+
+	if maximumBalanceCents, hasMaximumBalance, err := o.MaximumBalanceAsCents(); err != nil {
+		addError(err.Error(), "maximum_balance")
+	} else if hasMaximumBalance && minimumBalanceValid && maximumBalanceCents < minimumBalanceCents {
+		addError("must not be less than the minimum balance", "maximum_balance")
+	}
+
+	if o.SweepAccount != nil && *o.SweepAccount == o.Name {
+		addError("must not be the account itself", "sweep_account")
+	}
+
+	for ruleIndex, rule := range o.TransactionRules {
+		if rule == nil {
+			addError("must not be empty", "transaction_rules", ruleIndex)
+			continue
+		}
+
+		errs = append(errs, rule.validate(append(slices.Clone(accountPath), "transaction_rules", ruleIndex)...)...)
+	}
+
+	if o.MinimumBalanceTargets != nil {
+		errs = append(errs, o.MinimumBalanceTargets.validate(append(slices.Clone(accountPath), "minimum_balance_targets")...)...)
+	}
+
+	return errs
+}
+
+func (r *TransactionRule) validate(rulePath ...any) ValidationErrors {
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, append(slices.Clone(rulePath), path...)...))
+	}
+
+	if r.Action != TransactionRuleActionInclude && r.Action != TransactionRuleActionExclude {
+		addError(fmt.Sprintf("unsupported action '%s'; must be one of '%s' or '%s'", r.Action, TransactionRuleActionInclude, TransactionRuleActionExclude), "action")
+	}
+
+	if r.PayeePattern != nil {
+		if _, err := regexp.Compile(*r.PayeePattern); err != nil {
+			addError(fmt.Sprintf("invalid regular expression: %v", err), "payee_pattern")
+		}
+	}
+
+	minimumAmountCents, hasMinimumAmount, err := r.MinimumAmountAsCents()
+	if err != nil {
+		addError(err.Error(), "minimum_amount")
+	} else if minimumAmountCents < 0 {
+		addError("must not be negative", "minimum_amount")
+	}
+
+	if maximumAmountCents, hasMaximumAmount, err := r.MaximumAmountAsCents(); err != nil {
+		addError(err.Error(), "maximum_amount")
+	} else if maximumAmountCents < 0 {
+		addError("must not be negative", "maximum_amount")
+	} else if hasMinimumAmount && hasMaximumAmount && maximumAmountCents < minimumAmountCents {
+		addError("must not be less than the minimum amount", "maximum_amount")
+	}
+
+	return errs
+}
+
+func (t *MinimumBalanceTargets) validate(targetsPath ...any) ValidationErrors {
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, append(slices.Clone(targetsPath), path...)...))
+	}
+
+	if t.OutflowPercentage != nil && *t.OutflowPercentage < 0 {
+		addError("must not be negative", "outflow_percentage")
+	}
+
+	if t.CoverDaysBeyondWindow < 0 {
+		addError("must not be negative", "cover_days_beyond_window")
+	}
+
+	return errs
+}
+
+func (w *WindowConfig) validate() ValidationErrors {
+	var errs ValidationErrors
+	addError := func(message string, path ...any) {
+		errs = append(errs, newValidationError(message, append([]any{"calendar", "window"}, path...)...))
+	}
+
+	switch w.Cadence {
+	case WindowCadenceWeekly:
+		if _, err := calendar.ParseWeekday(w.GetWeekStart()); err != nil {
+			addError(err.Error(), "week_start")
+		}
+	case WindowCadencePayPeriod:
+		if _, hasPayPeriodStart, err := w.PayPeriodStartDate(); err != nil {
+			addError(err.Error(), "pay_period_start")
+		} else if !hasPayPeriodStart {
+			addError(fmt.Sprintf("is required when the cadence is '%s'", WindowCadencePayPeriod), "pay_period_start")
+		}
+
+		if w.GetPayPeriodDays() <= 0 {
+			addError("must be positive", "pay_period_days")
+		}
+	default:
+		addError(fmt.Sprintf("unsupported cadence '%s'; must be one of '%s' or '%s'", w.Cadence, WindowCadenceWeekly, WindowCadencePayPeriod), "cadence")
+	}
+
+	if w.GetPeriods() <= 0 {
+		addError("must be positive", "periods")
+	}
+
+	return errs
+}
+
+func isFlagColor(flagColor string) bool {
+	for _, supportedColor := range FlagColors {
+		if strings.EqualFold(supportedColor, strings.TrimSpace(flagColor)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newValidationError(message string, path ...any) *ValidationError {
+	var fieldBuilder strings.Builder
+	for _, segment := range path {
+		switch typedSegment := segment.(type) {
+		case int:
+			fieldBuilder.WriteString("[" + strconv.Itoa(typedSegment) + "]")
+		default:
+			if fieldBuilder.Len() > 0 {
+				fieldBuilder.WriteString(".")
+			}
+			fieldBuilder.WriteString(fmt.Sprint(typedSegment))
+		}
+	}
+
+	return &ValidationError{
+		Field:   fieldBuilder.String(),
+		Message: message,
+		path:    path,
+	}
+}
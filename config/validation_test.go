@@ -0,0 +1,216 @@
+package config_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/config"
+)
+
+var _ = Describe("Validate", func() {
+	var appConfig *config.Config
+
+	BeforeEach(func() {
+		appConfig = &config.Config{
+			ChainID:          8453,
+			ContractAddress:  "0x833589fcd6edb6e08f4c7c32d4f71b54bda02913",
+			Decimals:         6,
+			RecipientAddress: "0x407DF19995bBA21E71EC6e6b72FEba70318031Be",
+			YNABBudgetName:   "Budget",
+			YNABAccounts: &config.YNABAccountsConfig{
+				FundsOriginAccount:    "Wallet",
+				FundsRecipientAccount: "Offramp",
+				OfframpAccounts: []*config.YNABOfframpAccountConfig{
+					{
+						Name: "Checking",
+					},
+				},
+			},
+		}
+	})
+
+	It("accepts a valid configuration", func() {
+		Expect(appConfig.Validate()).To(Succeed(), "a valid configuration should pass validation")
+	})
+
+	DescribeTable("invalid configurations",
+		func(mutate func(*config.Config), expectedField string) {
+			mutate(appConfig)
+
+			err := appConfig.Validate()
+			Expect(err).To(HaveOccurred(), "the configuration should fail validation")
+
+			var validationErrs config.ValidationErrors
+			Expect(errors.As(err, &validationErrs)).To(BeTrue(), "the error should describe the validation failures")
+			Expect(validationErrs).To(ContainElement(HaveField("Field", expectedField)), "the offending field should be reported")
+		},
+		Entry("missing chain ID", func(c *config.Config) { c.ChainID = 0 }, "chain_id"),
+		Entry("negative chain ID", func(c *config.Config) { c.ChainID = -1 }, "chain_id"),
+		Entry("too few decimals", func(c *config.Config) { c.Decimals = 1 }, "decimals"),
+		Entry("too many decimals", func(c *config.Config) { c.Decimals = 19 }, "decimals"),
+		Entry("malformed contract address", func(c *config.Config) { c.ContractAddress = "0x1234" }, "contract_address"),
+		Entry("missing recipient address", func(c *config.Config) { c.RecipientAddress = "" }, "recipient_address"),
+		Entry("missing budget name", func(c *config.Config) { c.YNABBudgetName = "" }, "ynab_budget_name"),
+		Entry("missing accounts", func(c *config.Config) { c.YNABAccounts = nil }, "ynab_accounts"),
+		Entry("negative settlement delay", func(c *config.Config) {
+			c.Settlement = &config.SettlementConfig{DelayBusinessDays: -1}
+		}, "settlement.delay_business_days"),
+		Entry("unknown window cadence", func(c *config.Config) {
+			c.Calendar = &config.CalendarConfig{Window: &config.WindowConfig{Cadence: "monthly"}}
+		}, "calendar.window.cadence"),
+		Entry("unknown week start", func(c *config.Config) {
+			weekStart := "mondy"
+			c.Calendar = &config.CalendarConfig{Window: &config.WindowConfig{Cadence: config.WindowCadenceWeekly, WeekStart: &weekStart}}
+		}, "calendar.window.week_start"),
+		Entry("missing pay period start", func(c *config.Config) {
+			c.Calendar = &config.CalendarConfig{Window: &config.WindowConfig{Cadence: config.WindowCadencePayPeriod}}
+		}, "calendar.window.pay_period_start"),
+		Entry("malformed pay period start", func(c *config.Config) {
+			payPeriodStart := "01/03/2025"
+			c.Calendar = &config.CalendarConfig{Window: &config.WindowConfig{Cadence: config.WindowCadencePayPeriod, PayPeriodStart: &payPeriodStart}}
+		}, "calendar.window.pay_period_start"),
+		Entry("non-positive window periods", func(c *config.Config) {
+			periods := 0
+			c.Calendar = &config.CalendarConfig{Window: &config.WindowConfig{Cadence: config.WindowCadenceWeekly, Periods: &periods}}
+		}, "calendar.window.periods"),
+		Entry("missing funds origin account", func(c *config.Config) { c.YNABAccounts.FundsOriginAccount = "" }, "ynab_accounts.funds_origin_account"),
+		Entry("missing offramp accounts", func(c *config.Config) { c.YNABAccounts.OfframpAccounts = nil }, "ynab_accounts.offramp_accounts"),
+		Entry("duplicate offramp account names", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts = append(c.YNABAccounts.OfframpAccounts, &config.YNABOfframpAccountConfig{Name: "Checking"})
+		}, "ynab_accounts.offramp_accounts[1].name"),
+		Entry("funds recipient account named after the funds origin account", func(c *config.Config) {
+			c.YNABAccounts.FundsRecipientAccount = "Wallet"
+		}, "ynab_accounts.funds_recipient_account"),
+		Entry("offramp account named after the funds origin account", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].Name = "Wallet"
+		}, "ynab_accounts.offramp_accounts[0].name"),
+		Entry("offramp account named after the funds recipient account", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].Name = "Offramp"
+		}, "ynab_accounts.offramp_accounts[0].name"),
+		Entry("negative minimum balance target outflow percentage", func(c *config.Config) {
+			outflowPercentage := -10.0
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalanceTargets = &config.MinimumBalanceTargets{OutflowPercentage: &outflowPercentage}
+		}, "ynab_accounts.offramp_accounts[0].minimum_balance_targets.outflow_percentage"),
+		Entry("unknown flag color", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].ExcludedFlagColors = []string{"green", "gren"}
+		}, "ynab_accounts.offramp_accounts[0].excluded_flag_colors[1]"),
+		Entry("unknown included flag color", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].IncludedFlagColors = []string{"purpel"}
+		}, "ynab_accounts.offramp_accounts[0].included_flag_colors[0]"),
+		Entry("both included and excluded flag colors", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].ExcludedFlagColors = []string{"green"}
+			c.YNABAccounts.OfframpAccounts[0].IncludedFlagColors = []string{"red"}
+		}, "ynab_accounts.offramp_accounts[0].included_flag_colors"),
+		Entry("negative minimum balance", func(c *config.Config) {
+			minimumBalance := json.Number("-5")
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+		}, "ynab_accounts.offramp_accounts[0].minimum_balance"),
+		Entry("unknown account type", func(c *config.Config) {
+			accountType := "brokerage"
+			c.YNABAccounts.OfframpAccounts[0].Type = &accountType
+		}, "ynab_accounts.offramp_accounts[0].type"),
+		Entry("unknown funding mode", func(c *config.Config) {
+			fundingMode := "gross-ish"
+			c.YNABAccounts.OfframpAccounts[0].FundingMode = &fundingMode
+		}, "ynab_accounts.offramp_accounts[0].funding_mode"),
+		Entry("unknown transaction rule action", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].TransactionRules = []*config.TransactionRule{{Action: "ignore"}}
+		}, "ynab_accounts.offramp_accounts[0].transaction_rules[0].action"),
+		Entry("invalid transaction rule payee pattern", func(c *config.Config) {
+			payeePattern := "^Amazon("
+			c.YNABAccounts.OfframpAccounts[0].TransactionRules = []*config.TransactionRule{{Action: config.TransactionRuleActionExclude, PayeePattern: &payeePattern}}
+		}, "ynab_accounts.offramp_accounts[0].transaction_rules[0].payee_pattern"),
+		Entry("transaction rule maximum amount below minimum amount", func(c *config.Config) {
+			minimumAmount := json.Number("100")
+			maximumAmount := json.Number("50")
+			c.YNABAccounts.OfframpAccounts[0].TransactionRules = []*config.TransactionRule{{Action: config.TransactionRuleActionExclude, MinimumAmount: &minimumAmount, MaximumAmount: &maximumAmount}}
+		}, "ynab_accounts.offramp_accounts[0].transaction_rules[0].maximum_amount"),
+		Entry("net funding of a credit card", func(c *config.Config) {
+			accountType := config.AccountTypeCreditCard
+			fundingMode := config.FundingModeNet
+			c.YNABAccounts.OfframpAccounts[0].Type = &accountType
+			c.YNABAccounts.OfframpAccounts[0].FundingMode = &fundingMode
+		}, "ynab_accounts.offramp_accounts[0].funding_mode"),
+		Entry("unknown funding source", func(c *config.Config) {
+			fundingSource := "paycheck"
+			c.YNABAccounts.OfframpAccounts[0].FundingSource = &fundingSource
+		}, "ynab_accounts.offramp_accounts[0].funding_source"),
+		Entry("category funding source without categories", func(c *config.Config) {
+			fundingSource := config.FundingSourceCategory
+			c.YNABAccounts.OfframpAccounts[0].FundingSource = &fundingSource
+		}, "ynab_accounts.offramp_accounts[0].funding_categories"),
+		Entry("funding categories without the category funding source", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].FundingCategories = []string{"Groceries"}
+		}, "ynab_accounts.offramp_accounts[0].funding_categories"),
+		Entry("category funding source with net funding", func(c *config.Config) {
+			fundingSource := config.FundingSourceCategory
+			fundingMode := config.FundingModeNet
+			c.YNABAccounts.OfframpAccounts[0].FundingSource = &fundingSource
+			c.YNABAccounts.OfframpAccounts[0].FundingMode = &fundingMode
+			c.YNABAccounts.OfframpAccounts[0].FundingCategories = []string{"Groceries"}
+		}, "ynab_accounts.offramp_accounts[0].funding_source"),
+		Entry("unknown balance source", func(c *config.Config) {
+			balanceSource := "available_balance"
+			c.YNABAccounts.OfframpAccounts[0].BalanceSource = &balanceSource
+		}, "ynab_accounts.offramp_accounts[0].balance_source"),
+		Entry("unknown rounding mode", func(c *config.Config) {
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalanceRounding = "sideways"
+		}, "ynab_accounts.offramp_accounts[0].minimum_balance_rounding"),
+		Entry("excess minimum balance precision", func(c *config.Config) {
+			minimumBalance := json.Number("5.001")
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+		}, "ynab_accounts.offramp_accounts[0].minimum_balance"),
+		Entry("maximum balance below minimum balance", func(c *config.Config) {
+			minimumBalance := json.Number("100")
+			maximumBalance := json.Number("99.99")
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+			c.YNABAccounts.OfframpAccounts[0].MaximumBalance = &maximumBalance
+		}, "ynab_accounts.offramp_accounts[0].maximum_balance"),
+		Entry("malformed maximum balance alongside a negative minimum balance", func(c *config.Config) {
+			minimumBalance := json.Number("-5")
+			maximumBalance := json.Number("1.001")
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+			c.YNABAccounts.OfframpAccounts[0].MaximumBalance = &maximumBalance
+		}, "ynab_accounts.offramp_accounts[0].maximum_balance"),
+		Entry("malformed maximum balance alongside a malformed minimum balance", func(c *config.Config) {
+			minimumBalance := json.Number("5.001")
+			maximumBalance := json.Number("1.001")
+			c.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+			c.YNABAccounts.OfframpAccounts[0].MaximumBalance = &maximumBalance
+		}, "ynab_accounts.offramp_accounts[0].maximum_balance"),
+		Entry("sweeping into the account itself", func(c *config.Config) {
+			sweepAccount := "Checking"
+			c.YNABAccounts.OfframpAccounts[0].SweepAccount = &sweepAccount
+		}, "ynab_accounts.offramp_accounts[0].sweep_account"),
+	)
+
+	When("the account is a credit card", func() {
+		It("allows a negative minimum balance", func() {
+			accountType := config.AccountTypeCreditCard
+			minimumBalance := json.Number("-500.50")
+			appConfig.YNABAccounts.OfframpAccounts[0].Type = &accountType
+			appConfig.YNABAccounts.OfframpAccounts[0].MinimumBalance = &minimumBalance
+
+			Expect(appConfig.Validate()).To(Succeed(), "a credit card should allow a negative minimum balance")
+		})
+	})
+
+	It("accepts flag colors regardless of case", func() {
+		appConfig.YNABAccounts.OfframpAccounts[0].IncludedFlagColors = []string{"Red", "UNFLAGGED"}
+
+		Expect(appConfig.Validate()).To(Succeed(), "flag colors should be matched without regard to case")
+	})
+
+	When("the QR code type only requires a recipient address", func() {
+		It("does not require a contract address", func() {
+			qrCodeType := config.QRCodeTypeRecipientOnly
+			appConfig.QRCodeType = &qrCodeType
+			appConfig.ContractAddress = ""
+
+			Expect(appConfig.Validate()).To(Succeed(), "the contract address should not be required")
+		})
+	})
+})
@@ -7,10 +7,7 @@ import (
 
 // FormatCents formats the given expression of USD in cents to a dollar-and-cents string.
 func FormatCents(cents int) string {
-	remainderCents := cents % 100
-	dollars := (cents - remainderCents) / 100
-
-	return FormatDollarsAndCents(dollars, remainderCents)
+	return FormatDollarsAndCents(SplitCents(cents))
 }
 
 // FormatDollarsAndCents formats the given USD dollars and cents to a dollar-and-cents string.
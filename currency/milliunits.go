@@ -0,0 +1,19 @@
+package currency
+
+// MilliunitsPerCent is the number of milliunits - thousandths of a dollar, in which YNAB expresses amounts - in a cent.
+const MilliunitsPerCent = 10
+
+// MilliunitsToCents converts the given amount in milliunits into cents, discarding any fraction of a cent (rounding towards zero).
+func MilliunitsToCents(milliunits int) int {
+	return milliunits / MilliunitsPerCent
+}
+
+// CentsToMilliunits converts the given amount in cents into milliunits.
+func CentsToMilliunits(cents int) int {
+	return cents * MilliunitsPerCent
+}
+
+// SplitCents splits the given amount in cents into whole dollars and the remaining cents. Both have the sign of the given amount.
+func SplitCents(cents int) (int, int) {
+	return cents / 100, cents % 100
+}
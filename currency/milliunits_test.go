@@ -0,0 +1,37 @@
+package currency_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+var _ = Describe("Milliunits", func() {
+	DescribeTable("MilliunitsToCents",
+		func(milliunits int, expectedCents int) {
+			Expect(currency.MilliunitsToCents(milliunits)).To(Equal(expectedCents), "the milliunits should be converted to cents")
+		},
+		Entry("whole cents", 12340, 1234),
+		Entry("a fraction of a cent", 12345, 1234),
+		Entry("a negative amount", -12340, -1234),
+		Entry("a negative fraction of a cent, towards zero", -12345, -1234),
+		Entry("zero", 0, 0),
+	)
+
+	It("converts cents to milliunits", func() {
+		Expect(currency.CentsToMilliunits(-1234)).To(Equal(-12340), "the cents should be converted to milliunits")
+	})
+
+	DescribeTable("SplitCents",
+		func(cents int, expectedDollars int, expectedCents int) {
+			dollars, remainingCents := currency.SplitCents(cents)
+			Expect(dollars).To(Equal(expectedDollars), "the whole dollars should be returned")
+			Expect(remainingCents).To(Equal(expectedCents), "the remaining cents should be returned")
+		},
+		Entry("dollars and cents", 1234, 12, 34),
+		Entry("only cents", 34, 0, 34),
+		Entry("a negative amount", -1234, -12, -34),
+		Entry("a negative amount of less than a dollar", -34, 0, -34),
+	)
+})
@@ -0,0 +1,126 @@
+package currency
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Precision is the number of decimal places supported by USD.
+const Precision = 2
+
+// RoundingMode describes how amounts with more decimal places than Precision are to be handled.
+type RoundingMode string
+
+const (
+	// RoundingNone rejects amounts with more decimal places than Precision.
+	RoundingNone RoundingMode = ""
+	// RoundingHalfUp rounds to the nearest cent, rounding halves away from zero.
+	RoundingHalfUp RoundingMode = "half_up"
+	// RoundingDown truncates any fractional cents (rounding towards zero).
+	RoundingDown RoundingMode = "down"
+	// RoundingUp rounds any fractional cents away from zero.
+	RoundingUp RoundingMode = "up"
+)
+
+// IsValid returns true if the rounding mode is supported.
+func (r RoundingMode) IsValid() bool {
+	switch r {
+	case RoundingNone, RoundingHalfUp, RoundingDown, RoundingUp:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseCents parses the given decimal expression of USD (e.g., "10.5", "-5.25", or "$100") into cents.
+// If the amount has more decimal places than Precision, it is rounded according to the given rounding mode;
+// if the rounding mode is RoundingNone, an error is returned instead.
+func ParseCents(value string, rounding RoundingMode) (int, error) {
+	if !rounding.IsValid() {
+		return 0, fmt.Errorf("unsupported rounding mode '%s'", rounding)
+	}
+
+	trimmed := strings.TrimSpace(value)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(trimmed, "-"):
+		negative = true
+		trimmed = trimmed[1:]
+	case strings.HasPrefix(trimmed, "+"):
+		trimmed = trimmed[1:]
+	}
+
+	trimmed = strings.TrimPrefix(trimmed, "$")
+
+	wholePart, fractionalPart, _ := strings.Cut(trimmed, ".")
+	if wholePart == "" && fractionalPart == "" {
+		return 0, fmt.Errorf("'%s' is not a decimal amount", value)
+	}
+
+	if !isDigits(wholePart) || !isDigits(fractionalPart) {
+		return 0, fmt.Errorf("'%s' is not a decimal amount", value)
+	}
+
+	if wholePart == "" {
+		wholePart = "0"
+	}
+
+	dollars, err := strconv.Atoi(wholePart)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse '%s' as a decimal amount: %w", value, err)
+	}
+
+	excessDigits := ""
+	if len(fractionalPart) > Precision {
+		excessDigits = strings.TrimRight(fractionalPart[Precision:], "0")
+		fractionalPart = fractionalPart[:Precision]
+	}
+
+	// Right-pad so that, e.g., ".5" is treated as 50 cents rather than 5
+	fractionalPart += strings.Repeat("0", Precision-len(fractionalPart))
+
+	cents, err := strconv.Atoi(fractionalPart)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse '%s' as a decimal amount: %w", value, err)
+	}
+
+	if excessDigits != "" {
+		switch rounding {
+		case RoundingNone:
+			return 0, fmt.Errorf("'%s' has more than %d decimal places; configure a rounding mode to allow it to be rounded", value, Precision)
+		case RoundingHalfUp:
+			if excessDigits[0] >= '5' {
+				cents++
+			}
+		case RoundingUp:
+			cents++
+		case RoundingDown:
+			// the excess digits have already been discarded
+		}
+	}
+
+	if dollars > (math.MaxInt-cents)/100 {
+		return 0, fmt.Errorf("'%s' is too large to be expressed in cents", value)
+	}
+
+	totalCents := dollars*100 + cents
+
+	if negative {
+		return -totalCents, nil
+	}
+
+	return totalCents, nil
+}
+
+func isDigits(value string) bool {
+	for _, character := range value {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,80 @@
+package currency_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+var _ = Describe("Parse", func() {
+	Context("ParseCents", func() {
+		DescribeTable("valid amounts",
+			func(value string, rounding currency.RoundingMode, expectedCents int) {
+				cents, err := currency.ParseCents(value, rounding)
+				Expect(err).ToNot(HaveOccurred(), "parsing the amount should not fail")
+				Expect(cents).To(Equal(expectedCents), "the amount should be parsed to the correct number of cents")
+			},
+			Entry("whole dollars", "10", currency.RoundingNone, 1000),
+			Entry("a single fractional digit", "10.5", currency.RoundingNone, 1050),
+			Entry("only cents", ".75", currency.RoundingNone, 75),
+			Entry("a trailing decimal point", "10.", currency.RoundingNone, 1000),
+			Entry("a dollar sign", "$12.34", currency.RoundingNone, 1234),
+			Entry("an explicit positive sign", "+12.34", currency.RoundingNone, 1234),
+			Entry("surrounding whitespace", " 12.34 ", currency.RoundingNone, 1234),
+			Entry("a negative amount", "-5.25", currency.RoundingNone, -525),
+			Entry("a negative amount with a dollar sign", "-$5.25", currency.RoundingNone, -525),
+			Entry("insignificant trailing zeroes without rounding", "10.5000", currency.RoundingNone, 1050),
+			Entry("rounding half up below the midpoint", "10.504", currency.RoundingHalfUp, 1050),
+			Entry("rounding half up at the midpoint", "10.505", currency.RoundingHalfUp, 1051),
+			Entry("rounding half up using only the first excess digit", "10.5049", currency.RoundingHalfUp, 1050),
+			Entry("rounding half up into the next dollar", "10.995", currency.RoundingHalfUp, 1100),
+			Entry("rounding half up a negative amount away from zero", "-10.505", currency.RoundingHalfUp, -1051),
+			Entry("rounding half up a negative amount below the midpoint", "-10.504", currency.RoundingHalfUp, -1050),
+			Entry("rounding down", "10.509", currency.RoundingDown, 1050),
+			Entry("rounding down many decimal places", "10.5099999", currency.RoundingDown, 1050),
+			Entry("rounding down a negative amount towards zero", "-10.509", currency.RoundingDown, -1050),
+			Entry("rounding up", "10.501", currency.RoundingUp, 1051),
+			Entry("rounding up many decimal places", "10.5000001", currency.RoundingUp, 1051),
+			Entry("rounding up a negative amount away from zero", "-10.501", currency.RoundingUp, -1051),
+			Entry("rounding up only zeroes beyond the precision", "10.50000", currency.RoundingUp, 1050),
+			Entry("the largest amount that can be expressed in cents", "92233720368547758.07", currency.RoundingNone, 9223372036854775807),
+		)
+
+		DescribeTable("invalid amounts",
+			func(value string, rounding currency.RoundingMode) {
+				_, err := currency.ParseCents(value, rounding)
+				Expect(err).To(HaveOccurred(), "parsing the amount should fail")
+			},
+			Entry("more than two decimal places without rounding", "10.505", currency.RoundingNone),
+			Entry("a negative amount with more than two decimal places without rounding", "-10.505", currency.RoundingNone),
+			Entry("an empty value", "", currency.RoundingNone),
+			Entry("a lone sign", "-", currency.RoundingNone),
+			Entry("a lone decimal point", ".", currency.RoundingHalfUp),
+			Entry("a non-numeric value", "ten", currency.RoundingHalfUp),
+			Entry("an exponent", "1e3", currency.RoundingHalfUp),
+			Entry("multiple decimal points", "1.2.3", currency.RoundingHalfUp),
+			Entry("multiple signs", "--5", currency.RoundingHalfUp),
+			Entry("a thousands separator", "1,000", currency.RoundingHalfUp),
+			Entry("a non-numeric fractional part", "10.5x", currency.RoundingDown),
+			Entry("an unsupported rounding mode", "10.50", currency.RoundingMode("banker")),
+			Entry("a whole part too large to be expressed in cents", "92233720368547759", currency.RoundingNone),
+			Entry("an amount one cent too large to be expressed in cents", "92233720368547758.08", currency.RoundingNone),
+			Entry("an amount rounded up to one cent too large to be expressed in cents", "92233720368547758.071", currency.RoundingUp),
+			Entry("a negative amount too large to be expressed in cents", "-92233720368547759", currency.RoundingNone),
+			Entry("a whole part too large to be parsed", "99999999999999999999", currency.RoundingNone),
+		)
+	})
+
+	Context("RoundingMode", func() {
+		It("accepts each supported rounding mode", func() {
+			for _, rounding := range []currency.RoundingMode{currency.RoundingNone, currency.RoundingHalfUp, currency.RoundingDown, currency.RoundingUp} {
+				Expect(rounding.IsValid()).To(BeTrue(), "rounding mode '%s' should be valid", rounding)
+			}
+		})
+
+		It("rejects an unsupported rounding mode", func() {
+			Expect(currency.RoundingMode("banker").IsValid()).To(BeFalse(), "an unsupported rounding mode should not be valid")
+		})
+	})
+})
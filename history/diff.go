@@ -0,0 +1,88 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+// Difference is a value that differs between two runs.
+type Difference struct {
+	Field string
+	From  string // the value in the first run; empty if the first run has no such value
+	To    string // the value in the second run; empty if the second run has no such value
+}
+
+// Diff returns the values that differ between the given runs: the configuration, the window, the total,
+// and the funding of each account.
+func Diff(from, to *Run) []Difference {
+	var differences []Difference
+	addDifference := func(field, fromValue, toValue string) {
+		if fromValue != toValue {
+			differences = append(differences, Difference{
+				Field: field,
+				From:  fromValue,
+				To:    toValue,
+			})
+		}
+	}
+
+	addDifference("config_hash", from.ConfigHash, to.ConfigHash)
+	addDifference("window", formatWindow(from), formatWindow(to))
+	addDifference("total", currency.FormatCents(from.TotalCents), currency.FormatCents(to.TotalCents))
+	addDifference("transactions", fmt.Sprint(len(from.Transactions)), fmt.Sprint(len(to.Transactions)))
+
+	fromAccounts := mapAccountFunding(from)
+	toAccounts := mapAccountFunding(to)
+
+	accountNames := make([]string, 0, len(fromAccounts)+len(toAccounts))
+	for accountName := range fromAccounts {
+		accountNames = append(accountNames, accountName)
+	}
+	for accountName := range toAccounts {
+		if _, inFrom := fromAccounts[accountName]; !inFrom {
+			accountNames = append(accountNames, accountName)
+		}
+	}
+	sort.Strings(accountNames)
+
+	for _, accountName := range accountNames {
+		fromFunding, inFrom := fromAccounts[accountName]
+		toFunding, inTo := toAccounts[accountName]
+
+		for _, field := range []struct {
+			name  string
+			cents func(AccountFunding) int
+		}{
+			{name: "outbound", cents: func(a AccountFunding) int { return a.OutboundCents }},
+			{name: "adjustment", cents: func(a AccountFunding) int { return a.AdjustmentCents }},
+			{name: "sweep", cents: func(a AccountFunding) int { return a.SweepCents }},
+		} {
+			var fromValue, toValue string
+			if inFrom {
+				fromValue = currency.FormatCents(field.cents(fromFunding))
+			}
+			if inTo {
+				toValue = currency.FormatCents(field.cents(toFunding))
+			}
+
+			addDifference(accountName+"."+field.name, fromValue, toValue)
+		}
+	}
+
+	return differences
+}
+
+func formatWindow(run *Run) string {
+	return run.StartDate.String() + " - " + run.EndDate.String()
+}
+
+func mapAccountFunding(run *Run) map[string]AccountFunding {
+	fundingByAccount := make(map[string]AccountFunding, len(run.Accounts))
+	for _, accountFunding := range run.Accounts {
+		fundingByAccount[accountFunding.Account] = accountFunding
+	}
+
+	return fundingByAccount
+}
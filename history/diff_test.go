@@ -0,0 +1,72 @@
+package history_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/history"
+)
+
+var _ = Describe("Diff", func() {
+	newRun := func(runID string) *history.Run {
+		return &history.Run{
+			ID:         runID,
+			Timestamp:  time.Date(2025, time.July, 1, 8, 0, 0, 0, time.UTC),
+			ConfigHash: "abc123",
+			BudgetID:   "budget-0",
+			StartDate:  civil.Date{Year: 2025, Month: time.July, Day: 7},
+			EndDate:    civil.Date{Year: 2025, Month: time.July, Day: 13},
+			Accounts: []history.AccountFunding{
+				{
+					Account:       "Checking",
+					OutboundCents: 12345,
+				},
+			},
+			Transactions: []history.Transaction{
+				{
+					ID:               "transaction-0",
+					ImportID:         "offramp:" + runID + ":0",
+					AccountID:        "account-0",
+					Account:          "Checking",
+					AmountMilliunits: 123450,
+					Date:             "2025-07-01",
+				},
+			},
+			QRURL:      "ethereum:0xabc",
+			TotalCents: 12345,
+		}
+	}
+
+	It("returns the values that differ between the runs", func() {
+		from := newRun("run-0")
+
+		to := newRun("run-1")
+		to.TotalCents = 20000
+		to.Accounts = []history.AccountFunding{
+			{
+				Account:         "Checking",
+				OutboundCents:   12345,
+				AdjustmentCents: 7655,
+			},
+			{
+				Account:    "Savings",
+				SweepCents: 100,
+			},
+		}
+
+		Expect(history.Diff(from, to)).To(Equal([]history.Difference{
+			{Field: "total", From: "$123.45", To: "$200.00"},
+			{Field: "Checking.adjustment", From: "$0.00", To: "$76.55"},
+			{Field: "Savings.outbound", From: "", To: "$0.00"},
+			{Field: "Savings.adjustment", From: "", To: "$0.00"},
+			{Field: "Savings.sweep", From: "", To: "$1.00"},
+		}), "only the differing values should be returned")
+	})
+
+	It("returns nothing for identical runs", func() {
+		Expect(history.Diff(newRun("run-0"), newRun("run-0"))).To(BeEmpty(), "identical runs should have no differences")
+	})
+})
@@ -0,0 +1,123 @@
+// Package history keeps an append-only record of the offramp runs whose transactions were created in YNAB.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// maxLineBytes is the longest line, in bytes, that is read from the history file.
+const maxLineBytes = 1024 * 1024
+
+// Run is the record of an offramp run whose transactions were created in YNAB.
+type Run struct {
+	ID           string           `json:"id"`
+	Timestamp    time.Time        `json:"timestamp"`
+	ConfigHash   string           `json:"config_hash"` // the SHA-256 hash of the configuration file used for the run
+	BudgetID     string           `json:"budget_id"`
+	StartDate    civil.Date       `json:"start_date"`
+	EndDate      civil.Date       `json:"end_date"`
+	Accounts     []AccountFunding `json:"accounts"`
+	Transactions []Transaction    `json:"transactions"`
+	QRURL        string           `json:"qr_url,omitempty"`       // the URL presented as a QR code; empty if no funds were to be sent
+	TotalCents   int              `json:"total_cents"`            // the total to be sent through the offramp
+	RevertedRun  string           `json:"reverted_run,omitempty"` // if this run reverted another run, the ID of that run; its transactions are those that were deleted
+	Incomplete   bool             `json:"incomplete,omitempty"`   // true if not all of the run's transactions were created (or, for a revert, deleted), and those that were created (or deleted) were kept
+}
+
+// AccountFunding is the funding calculated for an account during a run.
+type AccountFunding struct {
+	Account         string `json:"account"`
+	OutboundCents   int    `json:"outbound_cents"`            // the total of the bills within the window
+	AdjustmentCents int    `json:"adjustment_cents"`          // the adjustment needed to maintain the minimum balance
+	AdjustmentRule  string `json:"adjustment_rule,omitempty"` // a description of the rule that determined the minimum balance
+	SweepCents      int    `json:"sweep_cents,omitempty"`     // the excess balance swept out of the account
+}
+
+// Transaction is a transaction created in YNAB during a run.
+type Transaction struct {
+	ID               string `json:"id"`
+	ImportID         string `json:"import_id"`
+	AccountID        string `json:"account_id"`
+	Account          string `json:"account"`
+	AmountMilliunits int    `json:"amount_milliunits"`
+	Date             string `json:"date"` // as YYYY-MM-DD
+	Memo             string `json:"memo,omitempty"`
+}
+
+// Append writes the given run to the end of the given history file, creating the file if it does not exist.
+func Append(path string, run *Run) error {
+	runBytes, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to marshal run '%s': %w", run.ID, err)
+	}
+
+	historyFile, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file '%s': %w", path, err)
+	}
+
+	if _, err := historyFile.Write(append(runBytes, '\n')); err != nil {
+		_ = historyFile.Close()
+		return fmt.Errorf("failed to write run '%s' to history file '%s': %w", run.ID, path, err)
+	}
+
+	if err := historyFile.Close(); err != nil {
+		return fmt.Errorf("failed to close history file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// Load reads all of the runs in the given history file, in the order in which they were run.
+// If the file does not exist, then no runs are returned.
+func Load(path string) ([]*Run, error) {
+	historyFile, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to open history file '%s': %w", path, err)
+	}
+	defer historyFile.Close()
+
+	scanner := bufio.NewScanner(historyFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+
+	var runs []*Run
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			return nil, fmt.Errorf("failed to parse line %d of history file '%s': %w", lineNumber, path, err)
+		}
+
+		runs = append(runs, &run)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file '%s': %w", path, err)
+	}
+
+	return runs, nil
+}
+
+// Find returns the run with the given ID.
+func Find(runs []*Run, runID string) (*Run, error) {
+	for _, run := range runs {
+		if run.ID == runID {
+			return run, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no run found with ID '%s'", runID)
+}
@@ -0,0 +1,99 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/history"
+)
+
+var _ = Describe("History", func() {
+	newRun := func(runID string) *history.Run {
+		return &history.Run{
+			ID:         runID,
+			Timestamp:  time.Date(2025, time.July, 1, 8, 0, 0, 0, time.UTC),
+			ConfigHash: "abc123",
+			BudgetID:   "budget-0",
+			StartDate:  civil.Date{Year: 2025, Month: time.July, Day: 7},
+			EndDate:    civil.Date{Year: 2025, Month: time.July, Day: 13},
+			Accounts: []history.AccountFunding{
+				{
+					Account:       "Checking",
+					OutboundCents: 12345,
+				},
+			},
+			Transactions: []history.Transaction{
+				{
+					ID:               "transaction-0",
+					ImportID:         "offramp:" + runID + ":0",
+					AccountID:        "account-0",
+					Account:          "Checking",
+					AmountMilliunits: 123450,
+					Date:             "2025-07-01",
+				},
+			},
+			QRURL:      "ethereum:0xabc",
+			TotalCents: 12345,
+		}
+	}
+
+	Context("Append and Load", func() {
+		It("reads back the appended runs in the order in which they were appended", func() {
+			historyPath := filepath.Join(GinkgoT().TempDir(), "history.jsonl")
+
+			Expect(history.Append(historyPath, newRun("run-0"))).To(Succeed(), "appending the first run should succeed")
+			Expect(history.Append(historyPath, newRun("run-1"))).To(Succeed(), "appending the second run should succeed")
+
+			runs, err := history.Load(historyPath)
+			Expect(err).ToNot(HaveOccurred(), "loading the history should not fail")
+			Expect(runs).To(HaveLen(2), "both runs should be read")
+			Expect(runs[0].ID).To(Equal("run-0"), "the first run should be read first")
+			Expect(runs[1].ID).To(Equal("run-1"), "the second run should be read second")
+			Expect(runs[1].StartDate).To(Equal(civil.Date{Year: 2025, Month: time.July, Day: 7}), "the window should be read")
+			Expect(runs[1].Transactions[0].ID).To(Equal("transaction-0"), "the transaction IDs should be read")
+
+			historyBytes, err := os.ReadFile(historyPath)
+			Expect(err).ToNot(HaveOccurred(), "reading the history file should not fail")
+			Expect(string(historyBytes)).To(HavePrefix(`{"id":"run-0",`), "each run should be written as a line of JSON")
+		})
+
+		It("returns no runs if the file does not exist", func() {
+			runs, err := history.Load(filepath.Join(GinkgoT().TempDir(), "missing.jsonl"))
+			Expect(err).ToNot(HaveOccurred(), "loading a missing history file should not fail")
+			Expect(runs).To(BeEmpty(), "there should be no runs")
+		})
+
+		It("reports the line that cannot be parsed", func() {
+			historyPath := filepath.Join(GinkgoT().TempDir(), "history.jsonl")
+			Expect(history.Append(historyPath, newRun("run-0"))).To(Succeed(), "appending the run should succeed")
+
+			historyFile, err := os.OpenFile(historyPath, os.O_APPEND|os.O_WRONLY, 0o600)
+			Expect(err).ToNot(HaveOccurred(), "opening the history file should not fail")
+			_, err = historyFile.WriteString("{not json\n")
+			Expect(err).ToNot(HaveOccurred(), "writing to the history file should not fail")
+			Expect(historyFile.Close()).To(Succeed(), "closing the history file should succeed")
+
+			_, err = history.Load(historyPath)
+			Expect(err).To(HaveOccurred(), "loading the history should fail")
+			Expect(err.Error()).To(ContainSubstring("line 2"), "the line that could not be parsed should be reported")
+		})
+	})
+
+	Context("Find", func() {
+		It("finds the run with the given ID", func() {
+			run, err := history.Find([]*history.Run{newRun("run-0"), newRun("run-1")}, "run-1")
+			Expect(err).ToNot(HaveOccurred(), "finding the run should not fail")
+			Expect(run.ID).To(Equal("run-1"), "the run with the given ID should be found")
+		})
+
+		It("returns an error if there is no run with the given ID", func() {
+			_, err := history.Find([]*history.Run{newRun("run-0")}, "run-1")
+			Expect(err).To(HaveOccurred(), "finding an unknown run should fail")
+		})
+	})
+})
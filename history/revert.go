@@ -0,0 +1,62 @@
+package history
+
+import (
+	"fmt"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// FindReversal returns the run that reverted the run with the given ID, or nil if it has not been reverted.
+// A revert that failed partway through is not a reversal; see RevertedImportIDs.
+func FindReversal(runs []*Run, runID string) *Run {
+	for _, run := range runs {
+		if run.RevertedRun == runID && !run.Incomplete {
+			return run
+		}
+	}
+
+	return nil
+}
+
+// RevertedImportIDs returns the import IDs of the transactions of the run with the given ID
+// that were deleted by reverts of it that failed partway through.
+func RevertedImportIDs(runs []*Run, runID string) map[string]bool {
+	importIDs := make(map[string]bool)
+	for _, run := range runs {
+		if run.RevertedRun != runID || !run.Incomplete {
+			continue
+		}
+
+		for _, transaction := range run.Transactions {
+			importIDs[transaction.ImportID] = true
+		}
+	}
+
+	return importIDs
+}
+
+// Verify returns an error if the given transaction as it now exists in YNAB no longer matches the transaction
+// as it was created, such as if it has since been edited or reconciled.
+func (t Transaction) Verify(current cliynab.TransactionDetail) error {
+	if current.AccountId != t.AccountID {
+		return fmt.Errorf("transaction ID '%s' is now in account ID '%s', but it was created in account ID '%s'", t.ID, current.AccountId, t.AccountID)
+	}
+
+	if current.Amount != t.AmountMilliunits {
+		return fmt.Errorf("transaction ID '%s' now has an amount of %d milliunits, but it was created with %d milliunits", t.ID, current.Amount, t.AmountMilliunits)
+	}
+
+	if current.Date != t.Date {
+		return fmt.Errorf("transaction ID '%s' is now dated %s, but it was created for %s", t.ID, current.Date, t.Date)
+	}
+
+	if current.ImportId == nil || *current.ImportId != t.ImportID {
+		return fmt.Errorf("transaction ID '%s' no longer has the import ID '%s' with which it was created", t.ID, t.ImportID)
+	}
+
+	if current.Cleared == "reconciled" {
+		return fmt.Errorf("transaction ID '%s' has been reconciled", t.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package history_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/history"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Revert", func() {
+	Context("FindReversal", func() {
+		It("finds the run that reverted the given run", func() {
+			runs := []*history.Run{
+				{ID: "run-0"},
+				{ID: "run-1", RevertedRun: "run-0"},
+			}
+
+			Expect(history.FindReversal(runs, "run-0")).To(Equal(runs[1]), "the reverting run should be found")
+			Expect(history.FindReversal(runs, "run-1")).To(BeNil(), "a run that has not been reverted should have no reversal")
+		})
+
+		It("does not treat a revert that failed partway through as a reversal", func() {
+			runs := []*history.Run{
+				{ID: "run-0"},
+				{ID: "run-1", RevertedRun: "run-0", Incomplete: true},
+			}
+
+			Expect(history.FindReversal(runs, "run-0")).To(BeNil(), "the run should not have been reverted")
+		})
+	})
+
+	Context("RevertedImportIDs", func() {
+		It("returns the import IDs deleted by the reverts that failed partway through", func() {
+			runs := []*history.Run{
+				{ID: "run-0"},
+				{ID: "run-1", RevertedRun: "run-0", Incomplete: true, Transactions: []history.Transaction{{ImportID: "offramp:run-0:0"}}},
+				{ID: "run-2", RevertedRun: "run-0", Incomplete: true, Transactions: []history.Transaction{{ImportID: "offramp:run-0:1"}}},
+				{ID: "run-3", RevertedRun: "run-other", Incomplete: true, Transactions: []history.Transaction{{ImportID: "offramp:run-other:0"}}},
+			}
+
+			Expect(history.RevertedImportIDs(runs, "run-0")).To(Equal(map[string]bool{
+				"offramp:run-0:0": true,
+				"offramp:run-0:1": true,
+			}), "the transactions deleted by each partial revert of the run should be returned")
+		})
+	})
+
+	Context("Verify", func() {
+		importID := "offramp:run-0:0"
+
+		created := history.Transaction{
+			ID:               "transaction-0",
+			ImportID:         importID,
+			AccountID:        "account-0",
+			AmountMilliunits: -12340,
+			Date:             "2025-07-01",
+		}
+
+		current := func() cliynab.TransactionDetail {
+			currentImportID := importID
+
+			return cliynab.TransactionDetail{
+				TransactionSummary: cliynab.TransactionSummary{
+					Id:        "transaction-0",
+					Date:      "2025-07-01",
+					Amount:    -12340,
+					Cleared:   "uncleared",
+					AccountId: "account-0",
+					ImportId:  &currentImportID,
+				},
+			}
+		}
+
+		It("accepts a transaction that is unchanged", func() {
+			Expect(created.Verify(current())).To(Succeed(), "an unchanged transaction should be verified")
+		})
+
+		DescribeTable("rejects a transaction that has changed",
+			func(change func(*cliynab.TransactionDetail), expectedError string) {
+				changed := current()
+				change(&changed)
+
+				err := created.Verify(changed)
+				Expect(err).To(HaveOccurred(), "a changed transaction should not be verified")
+				Expect(err.Error()).To(ContainSubstring(expectedError), "the change should be described")
+			},
+			Entry("amount", func(t *cliynab.TransactionDetail) { t.Amount = -100 }, "amount of -100 milliunits"),
+			Entry("account", func(t *cliynab.TransactionDetail) { t.AccountId = "account-1" }, "account ID 'account-1'"),
+			Entry("date", func(t *cliynab.TransactionDetail) { t.Date = "2025-07-02" }, "dated 2025-07-02"),
+			Entry("import ID", func(t *cliynab.TransactionDetail) { t.ImportId = nil }, "import ID"),
+			Entry("reconciliation", func(t *cliynab.TransactionDetail) { t.Cleared = "reconciled" }, "reconciled"),
+		)
+	})
+})
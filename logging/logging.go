@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+const (
+	// FormatText is the log format that writes records as key=value pairs.
+	FormatText = "text"
+	// FormatJSON is the log format that writes records as JSON objects.
+	FormatJSON = "json"
+)
+
+type loggerContextKey struct{}
+
+var discardLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// NewLogger creates a logger that writes to the given writer using the given format
+// (either "text" or "json") and only emits records at or above the given level.
+func NewLogger(writer io.Writer, format string, level string) (*slog.Logger, error) {
+	parsedLevel, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	handlerOptions := &slog.HandlerOptions{
+		Level: parsedLevel,
+	}
+
+	switch strings.ToLower(format) {
+	case FormatText, "":
+		return slog.New(slog.NewTextHandler(writer, handlerOptions)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(writer, handlerOptions)), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format '%s'; must be one of '%s' or '%s'", format, FormatText, FormatJSON)
+	}
+}
+
+// ParseLevel parses the given level name (debug, info, warn, or error) into a log level.
+func ParseLevel(level string) (slog.Level, error) {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(level)); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unsupported log level '%s': %w", level, err)
+	}
+
+	return parsed, nil
+}
+
+// WithLogger returns a copy of the given context that carries the given logger.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger carried by the given context.
+// If the context carries no logger, a logger that discards all records is returned.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(*slog.Logger); ok && logger != nil {
+		return logger
+	}
+
+	return discardLogger
+}
@@ -0,0 +1,80 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+)
+
+var _ = Describe("Logging", func() {
+	Context("NewLogger", func() {
+		It("writes JSON records when the JSON format is requested", func() {
+			buffer := &bytes.Buffer{}
+
+			logger, err := logging.NewLogger(buffer, "json", "info")
+			Expect(err).ToNot(HaveOccurred(), "creating the logger should not fail")
+
+			logger.Info("hello", "payee", "Electric Company")
+
+			record := make(map[string]any)
+			Expect(json.Unmarshal(buffer.Bytes(), &record)).To(Succeed(), "the record should be valid JSON")
+			Expect(record).To(HaveKeyWithValue("msg", "hello"), "the message should be recorded")
+			Expect(record).To(HaveKeyWithValue("payee", "Electric Company"), "the structured field should be recorded")
+		})
+
+		It("suppresses records below the configured level", func() {
+			buffer := &bytes.Buffer{}
+
+			logger, err := logging.NewLogger(buffer, "text", "warn")
+			Expect(err).ToNot(HaveOccurred(), "creating the logger should not fail")
+
+			logger.Debug("debug message")
+			logger.Info("info message")
+			Expect(buffer.String()).To(BeEmpty(), "records below the warn level should not be written")
+
+			logger.Warn("warn message")
+			Expect(buffer.String()).To(ContainSubstring("warn message"), "records at the warn level should be written")
+		})
+
+		When("the format is not supported", func() {
+			It("returns an error", func() {
+				_, err := logging.NewLogger(&bytes.Buffer{}, "xml", "info")
+				Expect(err).To(HaveOccurred(), "an unsupported format should fail")
+			})
+		})
+
+		When("the level is not supported", func() {
+			It("returns an error", func() {
+				_, err := logging.NewLogger(&bytes.Buffer{}, "text", "loud")
+				Expect(err).To(HaveOccurred(), "an unsupported level should fail")
+			})
+		})
+	})
+
+	Context("FromContext", func() {
+		It("returns the logger stored in the context", func() {
+			buffer := &bytes.Buffer{}
+
+			logger, err := logging.NewLogger(buffer, "text", "debug")
+			Expect(err).ToNot(HaveOccurred(), "creating the logger should not fail")
+
+			ctx := logging.WithLogger(context.Background(), logger)
+			logging.FromContext(ctx).Debug("from context")
+
+			Expect(buffer.String()).To(ContainSubstring("from context"), "the logger in the context should be used")
+		})
+
+		When("the context has no logger", func() {
+			It("returns a usable logger", func() {
+				Expect(func() {
+					logging.FromContext(context.Background()).Info("discarded")
+				}).ToNot(Panic(), "logging without a configured logger should not fail")
+			})
+		})
+	})
+})
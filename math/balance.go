@@ -1,87 +1,81 @@
 package math
 
 import (
+	"context"
 	"fmt"
-	"time"
-
-	"github.com/davidsteinsland/ynab-go/ynab"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
 	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
 // CalculateMinimumBalanceAdjustment returns the minimum balance adjustment
-// needed to, after all of the given transactions between now and the given date/time (inclusive),
+// needed to, after all of the given transactions between today (according to the given clock) and the given end date (inclusive),
 // maintain the given minimum account balance (expressed in cents).
+// The projected expenses considered by the calculation are written to the context's logger at debug level.
 func CalculateMinimumBalanceAdjustment(
-	account ynab.Account,
-	transactions []ynab.ScheduledTransactionDetail,
+	ctx context.Context,
+	clock civil.Clock,
+	account offrampynab.Account,
+	transactions []offrampynab.ScheduledTransactionDetail,
 	minimumAccountBalanceCents int,
-	endDateTime time.Time,
-	debug bool,
+	endDate civil.Date,
 ) (*offrampynab.MinimumBalanceAdjustment, error) {
+	logger := logging.FromContext(ctx).With("account", account.Name)
+
 	filteredTransactions := filterToAccountIDs(transactions, []string{account.Id})
 
-	// Print project bill expenses and time range (only in debug mode)
-	if debug {
-		yesterdayYear, yesterMonth, yesterday := time.Now().Add(-24 * time.Hour).Date()
-		yesterdayDate := time.Date(yesterdayYear, yesterMonth, yesterday, 0, 0, 0, 0, time.UTC)
-
-		fmt.Printf("Balance adjustment calculation for account '%s':\n", account.Name)
-		fmt.Printf("Time range: %s to %s\n", yesterdayDate.Format(time.DateOnly), endDateTime.Format(time.DateOnly))
-		fmt.Printf("Project bill expenses:\n")
-
-		totalExpenses := 0
-		for _, transaction := range filteredTransactions {
-			isBefore, err := offrampynab.IsScheduledBeforeInclusive(transaction.ScheduledTransactionSummary, yesterdayDate)
-			if err != nil {
-				continue
-			}
-			if isBefore {
-				continue
-			}
-
-			dayAfterEndYear, dayAfterEndMonth, dayAfterEndDate := endDateTime.Add(24 * time.Hour).Date()
-			endDate := time.Date(dayAfterEndYear, dayAfterEndMonth, dayAfterEndDate, 0, 0, 0, 0, time.UTC)
-
-			isAfter, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, endDate)
-			if err != nil {
-				continue
-			}
-			if isAfter {
-				continue
-			}
-
-			amountDollars, amountCents := toDollarsAndCents(transaction.Amount)
-
-			fmt.Printf("  - %s: %s\n", transaction.PayeeName, currency.FormatDollarsAndCents(amountDollars, amountCents))
-			totalExpenses += transaction.Amount
+	yesterday := civil.Today(clock).AddDays(-1)
+
+	logger.DebugContext(ctx, "calculating balance adjustment",
+		"start_date", yesterday.String(),
+		"end_date", endDate.String())
+
+	dayAfterEnd := endDate.AddDays(1)
+
+	totalExpenses := 0
+	for _, transaction := range filteredTransactions {
+		isBefore, err := offrampynab.IsScheduledBeforeInclusive(transaction.ScheduledTransactionSummary, yesterday)
+		if err != nil || isBefore {
+			continue
 		}
 
-		totalDollars, totalCents := toDollarsAndCents(totalExpenses)
+		isAfter, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, dayAfterEnd)
+		if err != nil || isAfter {
+			continue
+		}
+
+		amountDollars, amountCents := toDollarsAndCents(transaction.Amount)
 
-		fmt.Printf("Total expenses: %s\n", currency.FormatDollarsAndCents(totalDollars, totalCents))
+		logger.DebugContext(ctx, "projected bill expense",
+			"payee", transaction.PayeeName,
+			"amount", currency.FormatDollarsAndCents(amountDollars, amountCents),
+			"date", transaction.DateNext)
+		totalExpenses += transaction.Amount
 	}
 
-	effectiveBalanceThrough, err := CalculateEffectiveBalanceThrough(account.Balance, filteredTransactions, endDateTime)
+	totalDollars, totalCents := toDollarsAndCents(totalExpenses)
+
+	logger.DebugContext(ctx, "total projected expenses", "amount", currency.FormatDollarsAndCents(totalDollars, totalCents))
+
+	effectiveBalanceThrough, err := CalculateEffectiveBalanceThrough(clock, account.Balance, filteredTransactions, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate effective balance through: %w", err)
 	}
 
 	effectiveBalanceDollar, effectiveBalanceRemainingCents := toDollarsAndCents(effectiveBalanceThrough)
 
-	if debug {
-		startingDollars, startingCents := toDollarsAndCents(account.Balance)
-		fmt.Printf("Starting balance of account: %s\n", currency.FormatDollarsAndCents(startingDollars, startingCents))
-		fmt.Printf("Projected ending balance of account: %s\n", currency.FormatDollarsAndCents(effectiveBalanceDollar, effectiveBalanceRemainingCents))
-	}
+	startingDollars, startingCents := toDollarsAndCents(account.Balance)
+	logger.DebugContext(ctx, "projected account balance",
+		"starting_balance", currency.FormatDollarsAndCents(startingDollars, startingCents),
+		"ending_balance", currency.FormatDollarsAndCents(effectiveBalanceDollar, effectiveBalanceRemainingCents))
 
 	effectiveBalanceCents := effectiveBalanceDollar*100 + effectiveBalanceRemainingCents
 
 	if effectiveBalanceCents >= minimumAccountBalanceCents {
-		if debug {
-			fmt.Printf("Projected account balance meets or exceeds minimum account requirement (%s), so no balance adjustment will be created", currency.FormatCents(minimumAccountBalanceCents))
-		}
+		logger.DebugContext(ctx, "projected account balance meets or exceeds minimum account requirement; no balance adjustment will be created",
+			"minimum_balance", currency.FormatCents(minimumAccountBalanceCents))
 
 		return &offrampynab.MinimumBalanceAdjustment{}, nil
 	}
@@ -90,10 +84,9 @@ func CalculateMinimumBalanceAdjustment(
 	adjustmentRemainingCents := adjustmentTotalCents % 100
 	adjustmentDollars := (adjustmentTotalCents - adjustmentRemainingCents) / 100
 
-	if debug {
-		// Print a blank line for ease of reading
-		fmt.Println()
-	}
+	logger.DebugContext(ctx, "balance adjustment required",
+		"minimum_balance", currency.FormatCents(minimumAccountBalanceCents),
+		"adjustment", currency.FormatCents(adjustmentTotalCents))
 
 	return &offrampynab.MinimumBalanceAdjustment{
 		Dollars: adjustmentDollars,
@@ -101,29 +94,28 @@ func CalculateMinimumBalanceAdjustment(
 	}, nil
 }
 
-// CalculateEffectiveBalanceThrough returns the effective balance through the given end date.
+// CalculateEffectiveBalanceThrough returns the effective balance through the given end date,
+// applying the given transactions scheduled from today (according to the given clock) through the end date.
 // This is expressed as a YNAB transaction amount, not a number of cents.
 func CalculateEffectiveBalanceThrough(
+	clock civil.Clock,
 	currentAccountBalance int,
-	transactions []ynab.ScheduledTransactionDetail,
-	endDateTime time.Time,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	endDate civil.Date,
 ) (int, error) {
-	yesterdayYear, yesterMonth, yesterday := time.Now().Add(-24 * time.Hour).Date()
-	yesterdayDate := time.Date(yesterdayYear, yesterMonth, yesterday, 0, 0, 0, 0, time.UTC)
-
-	dayAfterEndYear, dayAfterEndMonth, dayAfterEndDate := endDateTime.Add(24 * time.Hour).Date()
-	endDate := time.Date(dayAfterEndYear, dayAfterEndMonth, dayAfterEndDate, 0, 0, 0, 0, time.UTC)
+	yesterday := civil.Today(clock).AddDays(-1)
+	dayAfterEnd := endDate.AddDays(1)
 
 	runningBalance := currentAccountBalance
 	for _, transaction := range transactions {
-		isBefore, err := offrampynab.IsScheduledBeforeInclusive(transaction.ScheduledTransactionSummary, yesterdayDate)
+		isBefore, err := offrampynab.IsScheduledBeforeInclusive(transaction.ScheduledTransactionSummary, yesterday)
 		if err != nil {
 			return 0, fmt.Errorf("failed to check if transaction to payee '%s' is before inclusive: %w", transaction.PayeeName, err)
 		} else if isBefore {
 			continue
 		}
 
-		isAfter, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, endDate)
+		isAfter, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, dayAfterEnd)
 		if err != nil {
 			return 0, fmt.Errorf("failed to check if transaction to payee '%s' is after inclusive: %w", transaction.PayeeName, err)
 		} else if isAfter {
@@ -135,3 +127,44 @@ func CalculateEffectiveBalanceThrough(
 
 	return runningBalance, nil
 }
+
+// CalculateNetFunding returns the funding needed so that the projected balance of the given account,
+// applying the given transactions day-by-day between today (according to the given clock) and the given end date (inclusive),
+// never falls below the given floor (expressed in cents) at the end of any day.
+// Unlike CalculateOutboundTransactions, this accounts for the account's current balance and any scheduled inflows.
+func CalculateNetFunding(
+	ctx context.Context,
+	clock civil.Clock,
+	account offrampynab.Account,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	floorCents int,
+	endDate civil.Date,
+) (*offrampynab.OutboundTransactionBalance, error) {
+	dailyBalances, err := ProjectDailyBalances(clock, account.Balance, filterToAccountIDs(transactions, []string{account.Id}), endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to project daily balances: %w", err)
+	}
+
+	lowestBalance, lowestDate := LowestBalance(account.Balance, dailyBalances)
+	lowestDollars, lowestRemainingCents := toDollarsAndCents(lowestBalance)
+	lowestCents := lowestDollars*100 + lowestRemainingCents
+
+	logger := logging.FromContext(ctx)
+	logger.DebugContext(ctx, "projected low-water mark",
+		"account", account.Name,
+		"lowest_balance", currency.FormatCents(lowestCents),
+		"date", lowestDate.String(),
+		"floor", currency.FormatCents(floorCents))
+
+	if lowestCents >= floorCents {
+		return &offrampynab.OutboundTransactionBalance{}, nil
+	}
+
+	shortfallCents := floorCents - lowestCents
+	shortfallRemainingCents := shortfallCents % 100
+
+	return &offrampynab.OutboundTransactionBalance{
+		Dollars: (shortfallCents - shortfallRemainingCents) / 100,
+		Cents:   shortfallRemainingCents,
+	}, nil
+}
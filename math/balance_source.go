@@ -0,0 +1,141 @@
+package math
+
+import (
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// BalanceSource describes which of an account's balances projections start from.
+type BalanceSource string
+
+const (
+	// BalanceSourceBalance starts projections from the account's balance, which includes
+	// all entered transactions - cleared or not, and regardless of their date.
+	BalanceSourceBalance BalanceSource = "balance"
+	// BalanceSourceClearedBalance starts projections from the account's cleared balance;
+	// uncleared transactions dated after today are applied on their dates.
+	BalanceSourceClearedBalance BalanceSource = "cleared_balance"
+	// BalanceSourceWorkingBalance starts projections from the account's balance as of today;
+	// transactions dated after today are applied on their dates.
+	BalanceSourceWorkingBalance BalanceSource = "working_balance"
+)
+
+// ApplyBalanceSource returns the balance from which projections of the given account should start, according to the given balance source,
+// along with the transactions that are to be applied on their dates during those projections.
+// futureTransactions are the account's entered (not scheduled) transactions; any dated on or before today (according to the given clock) are ignored.
+// Scheduled transactions that duplicate a future-dated entered transaction - the same account, date, and amount, and payee if known for both - are dropped
+// so that the transaction is counted only once.
+func ApplyBalanceSource(
+	clock civil.Clock,
+	account offrampynab.Account,
+	source BalanceSource,
+	scheduledTransactions []offrampynab.ScheduledTransactionDetail,
+	futureTransactions []offrampynab.TransactionDetail,
+) (int, []offrampynab.ScheduledTransactionDetail, error) {
+	today := civil.Today(clock)
+
+	var futureDated []offrampynab.TransactionDetail
+	for _, transaction := range futureTransactions {
+		if transaction.AccountId != account.Id {
+			continue
+		}
+
+		transactionDate, err := civil.ParseDate(transaction.Date)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to parse date of transaction to payee '%s': %w", transaction.PayeeName, err)
+		}
+
+		if transactionDate.After(today) {
+			futureDated = append(futureDated, transaction)
+		}
+	}
+
+	switch source {
+	case "", BalanceSourceBalance:
+		// The future-dated transactions are already reflected in the balance, so they only displace their scheduled duplicates
+		return account.Balance, removeDuplicatedScheduledTransactions(scheduledTransactions, futureDated), nil
+	case BalanceSourceWorkingBalance:
+		startingBalance := account.Balance
+		for _, transaction := range futureDated {
+			startingBalance -= transaction.Amount
+		}
+
+		return startingBalance, MergeEnteredTransactions(scheduledTransactions, futureDated), nil
+	case BalanceSourceClearedBalance:
+		startingBalance := account.ClearedBalance
+		for _, transaction := range futureDated {
+			if isCleared(transaction) {
+				startingBalance -= transaction.Amount
+			}
+		}
+
+		return startingBalance, MergeEnteredTransactions(scheduledTransactions, futureDated), nil
+	default:
+		return 0, nil, fmt.Errorf("unsupported balance source '%s'", source)
+	}
+}
+
+// MergeEnteredTransactions returns the given scheduled transactions along with the given entered (not scheduled) transactions,
+// each represented as a scheduled transaction on its date.
+// Scheduled transactions that duplicate an entered transaction - such as an occurrence that YNAB has already entered -
+// are dropped so that the transaction is counted only once.
+func MergeEnteredTransactions(
+	scheduledTransactions []offrampynab.ScheduledTransactionDetail,
+	enteredTransactions []offrampynab.TransactionDetail,
+) []offrampynab.ScheduledTransactionDetail {
+	merged := removeDuplicatedScheduledTransactions(scheduledTransactions, enteredTransactions)
+	for _, enteredTransaction := range enteredTransactions {
+		merged = append(merged, offrampynab.ToScheduledTransaction(enteredTransaction))
+	}
+
+	return merged
+}
+
+// removeDuplicatedScheduledTransactions returns the given scheduled transactions without those that duplicate any of the given entered transactions.
+// Each entered transaction duplicates at most one scheduled transaction.
+func removeDuplicatedScheduledTransactions(
+	scheduledTransactions []offrampynab.ScheduledTransactionDetail,
+	enteredTransactions []offrampynab.TransactionDetail,
+) []offrampynab.ScheduledTransactionDetail {
+	duplicated := make(map[int]bool)
+	for _, enteredTransaction := range enteredTransactions {
+		for scheduledIndex, scheduledTransaction := range scheduledTransactions {
+			if duplicated[scheduledIndex] || !isSameTransaction(scheduledTransaction, enteredTransaction) {
+				continue
+			}
+
+			duplicated[scheduledIndex] = true
+
+			break
+		}
+	}
+
+	deduplicated := make([]offrampynab.ScheduledTransactionDetail, 0, len(scheduledTransactions)-len(duplicated))
+	for scheduledIndex, scheduledTransaction := range scheduledTransactions {
+		if !duplicated[scheduledIndex] {
+			deduplicated = append(deduplicated, scheduledTransaction)
+		}
+	}
+
+	return deduplicated
+}
+
+func isSameTransaction(scheduledTransaction offrampynab.ScheduledTransactionDetail, enteredTransaction offrampynab.TransactionDetail) bool {
+	if scheduledTransaction.AccountId != enteredTransaction.AccountId ||
+		scheduledTransaction.DateNext != enteredTransaction.Date ||
+		scheduledTransaction.Amount != enteredTransaction.Amount {
+		return false
+	}
+
+	if scheduledTransaction.PayeeId == nil || enteredTransaction.PayeeId == nil {
+		return true
+	}
+
+	return *scheduledTransaction.PayeeId == *enteredTransaction.PayeeId
+}
+
+func isCleared(transaction offrampynab.TransactionDetail) bool {
+	return transaction.Cleared == "cleared" || transaction.Cleared == "reconciled"
+}
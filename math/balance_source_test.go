@@ -0,0 +1,213 @@
+package math_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("BalanceSource", func() {
+	Context("ApplyBalanceSource", func() {
+		var accountID string
+		var payeeID string
+		var account offrampynab.Account
+		var scheduledTransactions []offrampynab.ScheduledTransactionDetail
+		var futureTransactions []offrampynab.TransactionDetail
+		var tomorrow string
+
+		BeforeEach(func() {
+			accountID = "4f8e2a1c-6b3d-4c9e-8a7f-1d2e3f4a5b6c"
+			payeeID = "payee-electric"
+			today := civil.Today(clock)
+			tomorrow = today.AddDays(1).String()
+
+			account = offrampynab.Account{
+				Id:             accountID,
+				Balance:        400000, // 400.00 USD
+				ClearedBalance: 500000, // 500.00 USD
+			}
+
+			scheduledTransactions = []offrampynab.ScheduledTransactionDetail{
+				{
+					// duplicates the entered electric bill
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						Id:        "scheduled-electric",
+						AccountId: accountID,
+						Amount:    -75000,
+						DateNext:  tomorrow,
+						PayeeId:   &payeeID,
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						Id:        "scheduled-water",
+						AccountId: accountID,
+						Amount:    -30000,
+						DateNext:  tomorrow,
+					},
+				},
+			}
+
+			futureTransactions = []offrampynab.TransactionDetail{
+				{
+					// already counted in the balances
+					TransactionSummary: offrampynab.TransactionSummary{
+						Id:        "entered-today",
+						AccountId: accountID,
+						Amount:    -25000,
+						Date:      today.String(),
+						Cleared:   "uncleared",
+					},
+				},
+				{
+					TransactionSummary: offrampynab.TransactionSummary{
+						Id:        "entered-electric",
+						AccountId: accountID,
+						Amount:    -75000,
+						Date:      tomorrow,
+						Cleared:   "uncleared",
+						PayeeId:   &payeeID,
+					},
+				},
+			}
+		})
+
+		transactionIDs := func(transactions []offrampynab.ScheduledTransactionDetail) []string {
+			ids := make([]string, len(transactions))
+			for transactionIndex, transaction := range transactions {
+				ids[transactionIndex] = transaction.Id
+			}
+
+			return ids
+		}
+
+		When("the balance source is the balance", func() {
+			It("uses the balance and drops scheduled transactions already entered", func() {
+				startingBalance, transactions, err := math.ApplyBalanceSource(clock, account, math.BalanceSourceBalance, scheduledTransactions, futureTransactions)
+				Expect(err).ToNot(HaveOccurred(), "applying the balance source should not fail")
+				Expect(startingBalance).To(Equal(400000), "the balance should be used as-is")
+				Expect(transactionIDs(transactions)).To(ConsistOf("scheduled-water"), "the duplicated scheduled transaction should be dropped")
+			})
+		})
+
+		When("the balance source is the working balance", func() {
+			It("removes future-dated transactions from the balance and applies them on their dates", func() {
+				startingBalance, transactions, err := math.ApplyBalanceSource(clock, account, math.BalanceSourceWorkingBalance, scheduledTransactions, futureTransactions)
+				Expect(err).ToNot(HaveOccurred(), "applying the balance source should not fail")
+				Expect(startingBalance).To(Equal(475000), "the future-dated electric bill should be removed from the balance")
+				Expect(transactionIDs(transactions)).To(ConsistOf("scheduled-water", "entered-electric"), "the entered electric bill should replace its scheduled duplicate")
+
+				for _, transaction := range transactions {
+					if transaction.Id == "entered-electric" {
+						Expect(transaction.DateNext).To(Equal(tomorrow), "the entered transaction should be scheduled on its date")
+					}
+				}
+			})
+		})
+
+		When("the balance source is the cleared balance", func() {
+			It("uses the cleared balance and applies uncleared future-dated transactions on their dates", func() {
+				startingBalance, transactions, err := math.ApplyBalanceSource(clock, account, math.BalanceSourceClearedBalance, scheduledTransactions, futureTransactions)
+				Expect(err).ToNot(HaveOccurred(), "applying the balance source should not fail")
+				Expect(startingBalance).To(Equal(500000), "the cleared balance should be used as-is")
+				Expect(transactionIDs(transactions)).To(ConsistOf("scheduled-water", "entered-electric"), "the entered electric bill should replace its scheduled duplicate")
+			})
+
+			When("a future-dated transaction has already cleared", func() {
+				BeforeEach(func() {
+					futureTransactions[1].Cleared = "cleared"
+				})
+
+				It("removes it from the cleared balance so that it is counted once", func() {
+					startingBalance, _, err := math.ApplyBalanceSource(clock, account, math.BalanceSourceClearedBalance, scheduledTransactions, futureTransactions)
+					Expect(err).ToNot(HaveOccurred(), "applying the balance source should not fail")
+					Expect(startingBalance).To(Equal(575000), "the cleared electric bill should be removed from the cleared balance")
+				})
+			})
+		})
+
+		When("the payees of otherwise-matching transactions differ", func() {
+			BeforeEach(func() {
+				otherPayeeID := "payee-gas"
+				futureTransactions[1].PayeeId = &otherPayeeID
+			})
+
+			It("does not treat them as duplicates", func() {
+				_, transactions, err := math.ApplyBalanceSource(clock, account, math.BalanceSourceBalance, scheduledTransactions, futureTransactions)
+				Expect(err).ToNot(HaveOccurred(), "applying the balance source should not fail")
+				Expect(transactionIDs(transactions)).To(ConsistOf("scheduled-electric", "scheduled-water"), "no scheduled transaction should be dropped")
+			})
+		})
+
+		When("the balance source is not supported", func() {
+			It("returns an error", func() {
+				_, _, err := math.ApplyBalanceSource(clock, account, math.BalanceSource("available"), scheduledTransactions, futureTransactions)
+				Expect(err).To(HaveOccurred(), "an unsupported balance source should be rejected")
+			})
+		})
+	})
+
+	Context("MergeEnteredTransactions", func() {
+		It("adds entered transactions in place of their scheduled duplicates", func() {
+			accountID := "7c1d9e2f-3a4b-4c5d-8e6f-9a0b1c2d3e4f"
+			date := civil.Today(clock).String()
+
+			scheduledTransactions := []offrampynab.ScheduledTransactionDetail{
+				{
+					// YNAB has already entered this occurrence
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						Id:        "scheduled-rent",
+						AccountId: accountID,
+						Amount:    -1200000,
+						DateNext:  date,
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						Id:        "scheduled-internet",
+						AccountId: accountID,
+						Amount:    -60000,
+						DateNext:  date,
+					},
+				},
+			}
+
+			enteredTransactions := []offrampynab.TransactionDetail{
+				{
+					TransactionSummary: offrampynab.TransactionSummary{
+						Id:        "entered-rent",
+						AccountId: accountID,
+						Amount:    -1200000,
+						Date:      date,
+					},
+				},
+				{
+					TransactionSummary: offrampynab.TransactionSummary{
+						Id:        "entered-gym",
+						AccountId: accountID,
+						Amount:    -40000,
+						Date:      date,
+					},
+					PayeeName: "Gym",
+				},
+			}
+
+			merged := math.MergeEnteredTransactions(scheduledTransactions, enteredTransactions)
+
+			ids := make([]string, len(merged))
+			for transactionIndex, transaction := range merged {
+				ids[transactionIndex] = transaction.Id
+			}
+			Expect(ids).To(ConsistOf("scheduled-internet", "entered-rent", "entered-gym"), "the rent should be counted once")
+
+			outboundBalances, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, nil, merged, civil.Today(clock).AddDays(-1), civil.Today(clock).AddDays(1))
+			Expect(err).ToNot(HaveOccurred(), "calculating outbound transactions should not fail")
+			Expect(outboundBalances[accountID].ToCents()).To(Equal(130000), "the outbound balance should include the entered gym payment once")
+		})
+	})
+})
@@ -1,40 +1,43 @@
 package math_test
 
 import (
+	"context"
 	"time"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
 var _ = Describe("Balance", func() {
 	Context("CalculateMinimumBalanceAdjustment", func() {
 		When("there are no applicable transactions", func() {
 			It("calculates the amount needed to adjust the existing balance up to the minimum balance", func() {
-				account := ynab.Account{
+				account := offrampynab.Account{
 					Id:      "6dba52e7-3367-4d29-b246-95f7ff83495d",
 					Balance: 3000, // 3.00 USD
 				}
 
-				now := time.Now()
+				today := civil.Today(clock)
 
 				adjustment, err := math.CalculateMinimumBalanceAdjustment(
+					context.Background(),
+					clock,
 					account,
-					[]ynab.ScheduledTransactionDetail{
+					[]offrampynab.ScheduledTransactionDetail{
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								AccountId: "other-account",
 								Amount:    -1000, // 1.00 USD
-								DateNext:  now.Format(time.DateOnly),
+								DateNext:  today.String(),
 							},
 						},
 					},
 					1000, // 10.00 USD
-					now.Add(24*time.Hour),
-					false,
+					today.AddDays(1),
 				)
 
 				Expect(err).NotTo(HaveOccurred(), "calculating the minimum balance adjustment should not fail")
@@ -46,27 +49,28 @@ var _ = Describe("Balance", func() {
 			It("returns no adjustment", func() {
 				accountID := "27a41dc9-5d2b-4404-a4aa-b83e28d50586"
 
-				account := ynab.Account{
+				account := offrampynab.Account{
 					Id:      accountID,
 					Balance: 20000, // 20.00 USD
 				}
 
-				now := time.Now()
+				today := civil.Today(clock)
 
 				adjustment, err := math.CalculateMinimumBalanceAdjustment(
+					context.Background(),
+					clock,
 					account,
-					[]ynab.ScheduledTransactionDetail{
+					[]offrampynab.ScheduledTransactionDetail{
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								AccountId: accountID,
 								Amount:    -1000, // 1.00 USD
-								DateNext:  now.Format(time.DateOnly),
+								DateNext:  today.String(),
 							},
 						},
 					},
 					1000, // 10.00 USD, which should be less than the account balance
-					now.Add(24*time.Hour),
-					false,
+					today.AddDays(1),
 				)
 
 				Expect(err).NotTo(HaveOccurred(), "calculating the minimum balance adjustment should not fail")
@@ -78,27 +82,28 @@ var _ = Describe("Balance", func() {
 			It("calculates the amount needed to adjust the existing balance up to the minimum balance", func() {
 				accountID := "8e0e8d3f-5411-45e8-b47e-ae0a17486109"
 
-				account := ynab.Account{
+				account := offrampynab.Account{
 					Id:      accountID,
 					Balance: 2000, // 2.00 USD
 				}
 
-				now := time.Now()
+				today := civil.Today(clock)
 
 				adjustment, err := math.CalculateMinimumBalanceAdjustment(
+					context.Background(),
+					clock,
 					account,
-					[]ynab.ScheduledTransactionDetail{
+					[]offrampynab.ScheduledTransactionDetail{
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								AccountId: accountID,
 								Amount:    -1000, // 1.00 USD
-								DateNext:  now.Format(time.DateOnly),
+								DateNext:  today.String(),
 							},
 						},
 					},
 					1000, // 10.00 USD
-					now.Add(24*time.Hour),
-					false,
+					today.AddDays(1),
 				)
 
 				Expect(err).NotTo(HaveOccurred(), "calculating the minimum balance adjustment should not fail")
@@ -107,39 +112,133 @@ var _ = Describe("Balance", func() {
 		})
 	})
 
+	Context("CalculateNetFunding", func() {
+		var accountID string
+		var today civil.Date
+		var transactions []offrampynab.ScheduledTransactionDetail
+
+		BeforeEach(func() {
+			accountID = "a4c5d0f2-8f4b-4c8e-9d2a-7b5f1e3c9a10"
+			today = civil.Today(clock)
+
+			transactions = []offrampynab.ScheduledTransactionDetail{
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -80000, // 80.00 USD
+						DateNext:  today.String(),
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    100000, // 100.00 USD paycheck
+						DateNext:  today.AddDays(1).String(),
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -60000, // 60.00 USD
+						DateNext:  today.AddDays(2).String(),
+					},
+				},
+			}
+		})
+
+		It("funds only the shortfall at the lowest point of the window", func() {
+			account := offrampynab.Account{
+				Id:      accountID,
+				Balance: 50000, // 50.00 USD
+			}
+
+			funding, err := math.CalculateNetFunding(context.Background(), clock, account, transactions, 0, today.AddDays(2))
+			Expect(err).ToNot(HaveOccurred(), "calculating the net funding should not fail")
+			Expect(funding.ToCents()).To(Equal(3000), "the balance dips to -$30.00 before the paycheck arrives, so $30.00 is needed")
+		})
+
+		It("keeps the balance above the given floor", func() {
+			account := offrampynab.Account{
+				Id:      accountID,
+				Balance: 50000, // 50.00 USD
+			}
+
+			funding, err := math.CalculateNetFunding(context.Background(), clock, account, transactions, 2000, today.AddDays(2))
+			Expect(err).ToNot(HaveOccurred(), "calculating the net funding should not fail")
+			Expect(funding.ToCents()).To(Equal(5000), "the balance should be kept at $20.00 at its lowest point")
+		})
+
+		When("a bill repeats within the window", func() {
+			It("funds the shortfall after every occurrence of the bill", func() {
+				account := offrampynab.Account{
+					Id:      accountID,
+					Balance: 50000, // 50.00 USD
+				}
+
+				funding, err := math.CalculateNetFunding(context.Background(), clock, account, []offrampynab.ScheduledTransactionDetail{
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: accountID,
+							Amount:    -30000, // 30.00 USD every week
+							DateFirst: today.AddDays(1).String(),
+							DateNext:  today.AddDays(1).String(),
+							Frequency: "weekly",
+						},
+					},
+				}, 0, today.AddDays(20))
+				Expect(err).ToNot(HaveOccurred(), "calculating the net funding should not fail")
+				Expect(funding.ToCents()).To(Equal(4000), "the three weekly bills within the window take the balance to -$40.00")
+			})
+		})
+
+		When("the balance never falls below the floor", func() {
+			It("requires no funding", func() {
+				account := offrampynab.Account{
+					Id:      accountID,
+					Balance: 200000, // 200.00 USD
+				}
+
+				funding, err := math.CalculateNetFunding(context.Background(), clock, account, transactions, 0, today.AddDays(2))
+				Expect(err).ToNot(HaveOccurred(), "calculating the net funding should not fail")
+				Expect(funding.ToCents()).To(Equal(0), "no funding should be needed")
+			})
+		})
+	})
+
 	Context("CalculateEffectiveBalanceThrough", func() {
 		When("there are transactions before now", func() {
 			When("the transaction is before midnight today", func() {
 				It("does not count it in the balance", func() {
-					now := time.Now()
+					today := civil.Today(clock)
 
-					yesterdayDateString := now.Add(-24 * time.Hour).Format(time.DateOnly)
+					yesterdayDateString := today.AddDays(-1).String()
 
-					nowDateString := time.Now().Format(time.DateOnly)
+					nowDateString := civil.Today(clock).String()
 
 					balance, err := math.CalculateEffectiveBalanceThrough(
+						clock,
 						0,
-						[]ynab.ScheduledTransactionDetail{
+						[]offrampynab.ScheduledTransactionDetail{
 							{
-								ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+								ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 									DateNext: nowDateString,
 									Amount:   -200,
 								},
 							},
 							{
-								ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+								ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 									DateNext: yesterdayDateString,
 									Amount:   -100,
 								},
 							},
 							{
-								ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+								ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 									DateNext: nowDateString,
 									Amount:   -50,
 								},
 							},
 						},
-						now.Add(24*time.Hour),
+						today.AddDays(1),
 					)
 
 					Expect(err).ToNot(HaveOccurred(), "calculating the balance should not fail")
@@ -148,37 +247,74 @@ var _ = Describe("Balance", func() {
 			})
 		})
 
+		When("it is late in the evening west of UTC on the day daylight saving time ends", func() {
+			It("treats the local date as today", func() {
+				centralTime := time.FixedZone("CST", -6*60*60)
+				eveningClock := civil.FixedClock{Time: time.Date(2025, time.November, 2, 23, 30, 0, 0, centralTime)}
+
+				balance, err := math.CalculateEffectiveBalanceThrough(
+					eveningClock,
+					0,
+					[]offrampynab.ScheduledTransactionDetail{
+						{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+								DateNext: "2025-11-01",
+								Amount:   -100,
+							},
+						},
+						{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+								DateNext: "2025-11-02",
+								Amount:   -200,
+							},
+						},
+						{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+								DateNext: "2025-11-03",
+								Amount:   -50,
+							},
+						},
+					},
+					civil.Date{Year: 2025, Month: time.November, Day: 3},
+				)
+
+				Expect(err).ToNot(HaveOccurred(), "calculating the balance should not fail")
+				Expect(balance).To(Equal(-250), "the local date's transactions should be included even though it is already the next day in UTC")
+			})
+		})
+
 		When("there are transactions after the end date", func() {
 			It("does not count them in the balance", func() {
-				now := time.Now()
+				today := civil.Today(clock)
 
-				nowDateString := now.Format(time.DateOnly)
-				tomorrowDateString := now.Add(24 * time.Hour).Format(time.DateOnly)
-				dayAfterTomorrowDateString := now.Add(48 * time.Hour).Format(time.DateOnly)
+				nowDateString := today.String()
+				tomorrowDateString := today.AddDays(1).String()
+				dayAfterTomorrowDateString := today.AddDays(2).String()
 
 				balance, err := math.CalculateEffectiveBalanceThrough(
+					clock,
 					0,
-					[]ynab.ScheduledTransactionDetail{
+					[]offrampynab.ScheduledTransactionDetail{
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								DateNext: nowDateString,
 								Amount:   -200,
 							},
 						},
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								DateNext: tomorrowDateString,
 								Amount:   -100,
 							},
 						},
 						{
-							ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+							ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 								DateNext: dayAfterTomorrowDateString,
 								Amount:   -50,
 							},
 						},
 					},
-					now.Add(24*time.Hour),
+					today.AddDays(1),
 				)
 
 				Expect(err).ToNot(HaveOccurred(), "calculating the balance should not fail")
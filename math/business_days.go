@@ -0,0 +1,35 @@
+package math
+
+import (
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// ShiftBillsToPriorBusinessDays returns the given scheduled transactions with each bill (outbound transaction)
+// that is scheduled on a weekend or holiday moved to the business day before it, as banks typically pay such bills early.
+// Inbound transactions are left on their scheduled dates.
+// Scheduled transactions that duplicate one of the given entered transactions are dropped first: YNAB enters a bill on the date
+// for which it was scheduled, so the bill could no longer be matched to its entered duplicate once it had been moved.
+func ShiftBillsToPriorBusinessDays(
+	businessCalendar *calendar.Calendar,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	enteredTransactions []offrampynab.TransactionDetail,
+) ([]offrampynab.ScheduledTransactionDetail, error) {
+	shifted := removeDuplicatedScheduledTransactions(transactions, enteredTransactions)
+	for transactionIndex, transaction := range shifted {
+		if transaction.Amount >= 0 {
+			continue
+		}
+
+		scheduledDate, err := offrampynab.ParseScheduledDate(transaction.ScheduledTransactionSummary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date of transaction to payee '%s': %w", transaction.PayeeName, err)
+		}
+
+		shifted[transactionIndex].DateNext = businessCalendar.PreviousBusinessDay(scheduledDate).String()
+	}
+
+	return shifted, nil
+}
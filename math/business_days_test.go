@@ -0,0 +1,58 @@
+package math_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("BusinessDays", func() {
+	Context("ShiftBillsToPriorBusinessDays", func() {
+		It("moves bills on weekends and holidays to the prior business day", func() {
+			// 2025-07-04 is a Friday holiday
+			businessCalendar := calendar.New([]civil.Date{civil.Date{Year: 2025, Month: time.July, Day: 4}})
+
+			transactions := []offrampynab.ScheduledTransactionDetail{
+				{ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{Id: "rent", Amount: -1200000, DateNext: "2025-07-05"}},
+				{ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{Id: "internet", Amount: -60000, DateNext: "2025-07-07"}},
+				{ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{Id: "paycheck", Amount: 2000000, DateNext: "2025-07-06"}},
+			}
+
+			shifted, err := math.ShiftBillsToPriorBusinessDays(businessCalendar, transactions, nil)
+			Expect(err).ToNot(HaveOccurred(), "shifting the bills should not fail")
+			Expect(shifted[0].DateNext).To(Equal("2025-07-03"), "the Saturday bill should move past the holiday to Thursday")
+			Expect(shifted[1].DateNext).To(Equal("2025-07-07"), "the Monday bill should not move")
+			Expect(shifted[2].DateNext).To(Equal("2025-07-06"), "inbound transactions should not move")
+			Expect(transactions[0].DateNext).To(Equal("2025-07-05"), "the given transactions should not be modified")
+		})
+
+		It("drops a weekend bill that has already been entered rather than counting it twice", func() {
+			businessCalendar := calendar.New(nil)
+
+			payeeID := "electric-co"
+			transactions := []offrampynab.ScheduledTransactionDetail{
+				// 2025-07-05 is a Saturday
+				{ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{Id: "electric", AccountId: "account-0", PayeeId: &payeeID, Amount: -80000, DateNext: "2025-07-05"}},
+				{ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{Id: "water", AccountId: "account-0", Amount: -30000, DateNext: "2025-07-06"}},
+			}
+			enteredTransactions := []offrampynab.TransactionDetail{
+				{TransactionSummary: offrampynab.TransactionSummary{Id: "entered-electric", AccountId: "account-0", PayeeId: &payeeID, Amount: -80000, Date: "2025-07-05"}},
+			}
+
+			shifted, err := math.ShiftBillsToPriorBusinessDays(businessCalendar, transactions, enteredTransactions)
+			Expect(err).ToNot(HaveOccurred(), "shifting the bills should not fail")
+			Expect(shifted).To(HaveLen(1), "the bill that has already been entered should be dropped")
+			Expect(shifted[0].Id).To(Equal("water"), "the bill that has not been entered should remain")
+			Expect(shifted[0].DateNext).To(Equal("2025-07-04"), "the remaining Sunday bill should move to Friday")
+
+			merged := math.MergeEnteredTransactions(shifted, enteredTransactions)
+			Expect(merged).To(HaveLen(2), "the entered bill should be counted once alongside the remaining bill")
+		})
+	})
+})
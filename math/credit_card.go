@@ -0,0 +1,75 @@
+package math
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// CalculateCreditCardFunding returns the funding needed to pay the given credit card account.
+// The card is paid the amount available in its payment category (expressed as a YNAB amount), no more than the card's debt,
+// less any payments to the card scheduled by the given transactions within the given start and end dates (inclusive).
+// Charges to the card are not funded directly; they are funded as money is budgeted to the card's payment category.
+// The given transactions are expected to list each transfer once, as YNAB lists scheduled transfers.
+func CalculateCreditCardFunding(
+	ctx context.Context,
+	account offrampynab.Account,
+	paymentCategoryAvailable int,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	startDate civil.Date,
+	endDate civil.Date,
+) (*offrampynab.OutboundTransactionBalance, error) {
+	filteredByDate, err := filterTransactionsByDateRange(transactions, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transactions by date range: %w", err)
+	}
+
+	logger := logging.FromContext(ctx).With("account", account.Name)
+
+	scheduledPayments := 0
+	for _, transaction := range filteredByDate {
+		if transaction.TransferAccountId == nil {
+			continue
+		}
+
+		var payment int
+		switch {
+		case transaction.AccountId == account.Id && transaction.Amount > 0:
+			// scheduled in the card from the paying account
+			payment = transaction.Amount
+		case *transaction.TransferAccountId == account.Id && transaction.Amount < 0:
+			// scheduled in the paying account
+			payment = -transaction.Amount
+		default:
+			continue
+		}
+
+		dollars, cents := toDollarsAndCents(payment)
+		logger.DebugContext(ctx, "scheduled credit card payment",
+			"amount", currency.FormatDollarsAndCents(dollars, cents),
+			"date", transaction.DateNext)
+
+		scheduledPayments += payment
+	}
+
+	debt := max(-account.Balance, 0)
+	funding := max(min(max(paymentCategoryAvailable, 0), debt)-scheduledPayments, 0)
+
+	availableDollars, availableCents := toDollarsAndCents(paymentCategoryAvailable)
+	debtDollars, debtCents := toDollarsAndCents(debt)
+	logger.DebugContext(ctx, "calculated credit card funding",
+		"payment_category_available", currency.FormatDollarsAndCents(availableDollars, availableCents),
+		"debt", currency.FormatDollarsAndCents(debtDollars, debtCents),
+		"funding_cents", toCents(funding))
+
+	dollars, cents := toDollarsAndCents(funding)
+
+	return &offrampynab.OutboundTransactionBalance{
+		Dollars: dollars,
+		Cents:   cents,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package math_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("CreditCard", func() {
+	Context("CalculateCreditCardFunding", func() {
+		var cardAccountID string
+		var checkingAccountID string
+		var account offrampynab.Account
+		var transactions []offrampynab.ScheduledTransactionDetail
+		var date civil.Date
+
+		BeforeEach(func() {
+			cardAccountID = "card"
+			checkingAccountID = "checking"
+			date = civil.Date{Year: 2025, Month: time.June, Day: 15}
+
+			account = offrampynab.Account{
+				Id:      cardAccountID,
+				Name:    "Rewards Card",
+				Balance: -800000, // 800.00 USD owed
+			}
+
+			transactions = []offrampynab.ScheduledTransactionDetail{
+				{
+					// a charge, which is not funded directly
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: cardAccountID,
+						Amount:    -120000,
+						DateNext:  date.String(),
+					},
+				},
+				{
+					// a payment scheduled in the paying account
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId:         checkingAccountID,
+						TransferAccountId: &cardAccountID,
+						Amount:            -200000,
+						DateNext:          date.String(),
+					},
+				},
+				{
+					// a payment scheduled in the card, but outside of the window
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId:         cardAccountID,
+						TransferAccountId: &checkingAccountID,
+						Amount:            50000,
+						DateNext:          date.AddDays(1).String(),
+					},
+				},
+			}
+		})
+
+		It("funds the payment category's available amount less scheduled payments", func() {
+			funding, err := math.CalculateCreditCardFunding(context.Background(), account, 650000, transactions, date, date)
+			Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+			Expect(funding.ToCents()).To(Equal(45000), "the card should be funded $650.00 available less the $200.00 payment")
+		})
+
+		It("counts payments scheduled in the card within the window", func() {
+			funding, err := math.CalculateCreditCardFunding(context.Background(), account, 650000, transactions, date, date.AddDays(1))
+			Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+			Expect(funding.ToCents()).To(Equal(40000), "both payments should be deducted")
+		})
+
+		When("more is available than is owed", func() {
+			It("funds no more than the debt", func() {
+				funding, err := math.CalculateCreditCardFunding(context.Background(), account, 1000000, nil, date, date)
+				Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+				Expect(funding.ToCents()).To(Equal(80000), "the funding should be capped at the debt")
+			})
+		})
+
+		When("the scheduled payments cover the available amount", func() {
+			It("does not fund the card", func() {
+				funding, err := math.CalculateCreditCardFunding(context.Background(), account, 150000, transactions, date, date)
+				Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+				Expect(funding.ToCents()).To(BeZero(), "no funding should be needed")
+			})
+		})
+	})
+})
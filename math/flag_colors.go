@@ -0,0 +1,49 @@
+package math
+
+import (
+	"fmt"
+	"strings"
+
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// FlagColorFilter describes the flag colors of the transactions to be counted in calculations.
+// Flag colors are matched without regard to case, and an empty flag color matches transactions without a flag color.
+type FlagColorFilter struct {
+	Included []string // if not empty, only transactions with one of these flag colors are counted
+	Excluded []string // transactions with any of these flag colors are not counted
+}
+
+// Excludes returns true if the given transaction is not to be counted, along with a description of why.
+func (f *FlagColorFilter) Excludes(transaction offrampynab.ScheduledTransactionDetail) (bool, string) {
+	if f == nil {
+		return false, ""
+	}
+
+	flagColor := ""
+	description := "no flag color"
+	if transaction.FlagColor != nil && *transaction.FlagColor != "" {
+		flagColor = *transaction.FlagColor
+		description = fmt.Sprintf("flag color '%s'", flagColor)
+	}
+
+	if len(f.Included) > 0 && !containsFlagColor(f.Included, flagColor) {
+		return true, description + " is not included"
+	}
+
+	if containsFlagColor(f.Excluded, flagColor) {
+		return true, "excluded " + description
+	}
+
+	return false, ""
+}
+
+func containsFlagColor(flagColors []string, flagColor string) bool {
+	for _, candidate := range flagColors {
+		if strings.EqualFold(strings.TrimSpace(candidate), flagColor) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,42 @@
+package math_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("FlagColorFilter", func() {
+	withFlagColor := func(flagColor *string) offrampynab.ScheduledTransactionDetail {
+		return offrampynab.ScheduledTransactionDetail{
+			ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+				FlagColor: flagColor,
+			},
+		}
+	}
+
+	red := "red"
+	blue := "blue"
+
+	DescribeTable("Excludes",
+		func(filter *math.FlagColorFilter, flagColor *string, expectedExcluded bool) {
+			excluded, reason := filter.Excludes(withFlagColor(flagColor))
+			Expect(excluded).To(Equal(expectedExcluded), "the transaction should be excluded as expected")
+			if expectedExcluded {
+				Expect(reason).ToNot(BeEmpty(), "a reason should be given for the exclusion")
+			}
+		},
+		Entry("no filter", nil, &red, false),
+		Entry("excluded color", &math.FlagColorFilter{Excluded: []string{"red"}}, &red, true),
+		Entry("excluded color in a different case", &math.FlagColorFilter{Excluded: []string{"Red"}}, &red, true),
+		Entry("color not excluded", &math.FlagColorFilter{Excluded: []string{"red"}}, &blue, false),
+		Entry("excluded unflagged transaction", &math.FlagColorFilter{Excluded: []string{""}}, nil, true),
+		Entry("unflagged transaction not excluded", &math.FlagColorFilter{Excluded: []string{"red"}}, nil, false),
+		Entry("included color", &math.FlagColorFilter{Included: []string{"RED"}}, &red, false),
+		Entry("color not included", &math.FlagColorFilter{Included: []string{"red"}}, &blue, true),
+		Entry("unflagged transaction not included", &math.FlagColorFilter{Included: []string{"red"}}, nil, true),
+		Entry("included unflagged transaction", &math.FlagColorFilter{Included: []string{"red", ""}}, nil, false),
+	)
+})
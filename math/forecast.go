@@ -0,0 +1,84 @@
+package math
+
+import (
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// AccountForecast is a day-by-day projection of an account's balance.
+type AccountForecast struct {
+	AccountID            string         `json:"account_id"`
+	AccountName          string         `json:"account_name"`
+	StartingBalanceCents int            `json:"starting_balance_cents"`
+	MinimumBalanceCents  *int           `json:"minimum_balance_cents,omitempty"` // nil if the account has no minimum balance
+	LowestBalanceCents   int            `json:"lowest_balance_cents"`
+	LowestBalanceDate    string         `json:"lowest_balance_date,omitempty"` // empty if the starting balance is the lowest balance
+	Days                 []*ForecastDay `json:"days"`
+}
+
+// ForecastDay is the projection of an account's balance for a single day.
+type ForecastDay struct {
+	Date         string           `json:"date"`
+	Entries      []*ForecastEntry `json:"entries"`
+	BalanceCents int              `json:"balance_cents"`
+	BelowMinimum bool             `json:"below_minimum"`
+}
+
+// ForecastEntry is a single scheduled transaction within a forecast.
+type ForecastEntry struct {
+	Payee       string `json:"payee"`
+	AmountCents int    `json:"amount_cents"`
+}
+
+// ForecastAccount projects the balance of the given account for each day from today (according to the given clock) through the given end date (inclusive)
+// using the given scheduled transactions. Days whose ending balance is below the given minimum balance (expressed in cents), if any, are flagged.
+func ForecastAccount(
+	clock civil.Clock,
+	account offrampynab.Account,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	minimumBalanceCents *int,
+	endDate civil.Date,
+) (*AccountForecast, error) {
+	dailyBalances, err := ProjectDailyBalances(clock, account.Balance, filterToAccountIDs(transactions, []string{account.Id}), endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to project daily balances: %w", err)
+	}
+
+	lowestBalance, lowestDate := LowestBalance(account.Balance, dailyBalances)
+
+	forecast := &AccountForecast{
+		AccountID:            account.Id,
+		AccountName:          account.Name,
+		StartingBalanceCents: toCents(account.Balance),
+		MinimumBalanceCents:  minimumBalanceCents,
+		LowestBalanceCents:   toCents(lowestBalance),
+		Days:                 make([]*ForecastDay, 0, len(dailyBalances)),
+	}
+
+	if !lowestDate.IsZero() {
+		forecast.LowestBalanceDate = lowestDate.String()
+	}
+
+	for _, dailyBalance := range dailyBalances {
+		day := &ForecastDay{
+			Date:         dailyBalance.Date.String(),
+			Entries:      make([]*ForecastEntry, 0, len(dailyBalance.Transactions)),
+			BalanceCents: toCents(dailyBalance.Balance),
+		}
+
+		day.BelowMinimum = minimumBalanceCents != nil && day.BalanceCents < *minimumBalanceCents
+
+		for _, transaction := range dailyBalance.Transactions {
+			day.Entries = append(day.Entries, &ForecastEntry{
+				Payee:       transaction.PayeeName,
+				AmountCents: toCents(transaction.Amount),
+			})
+		}
+
+		forecast.Days = append(forecast.Days, day)
+	}
+
+	return forecast, nil
+}
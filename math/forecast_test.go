@@ -0,0 +1,128 @@
+package math_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Forecast", func() {
+	Context("ForecastAccount", func() {
+		It("builds a daily ledger and flags days below the minimum balance", func() {
+			accountID := "forecast-account"
+			today := civil.Today(clock)
+			minimumBalanceCents := 5000
+
+			forecast, err := math.ForecastAccount(
+				clock,
+				offrampynab.Account{
+					Id:      accountID,
+					Name:    "Checking",
+					Balance: 100000, // 100.00 USD
+				},
+				[]offrampynab.ScheduledTransactionDetail{
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: accountID,
+							Amount:    -70000,
+							DateNext:  today.AddDays(1).String(),
+						},
+						PayeeName: "Landlord",
+					},
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: accountID,
+							Amount:    40000,
+							DateNext:  today.AddDays(2).String(),
+						},
+						PayeeName: "Employer",
+					},
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: "other-account",
+							Amount:    -999000,
+							DateNext:  today.AddDays(1).String(),
+						},
+					},
+				},
+				&minimumBalanceCents,
+				today.AddDays(2),
+			)
+
+			Expect(err).ToNot(HaveOccurred(), "forecasting the account should not fail")
+			Expect(forecast.AccountName).To(Equal("Checking"), "the account should be identified")
+			Expect(forecast.StartingBalanceCents).To(Equal(10000), "the starting balance should be expressed in cents")
+			Expect(forecast.Days).To(HaveLen(3), "there should be a day for today and the two following days")
+
+			Expect(forecast.Days[0].BalanceCents).To(Equal(10000), "nothing is scheduled today")
+			Expect(forecast.Days[0].BelowMinimum).To(BeFalse(), "today's balance is above the minimum")
+
+			Expect(forecast.Days[1].Entries).To(HaveLen(1), "only this account's transaction should be in the ledger")
+			Expect(forecast.Days[1].Entries[0].Payee).To(Equal("Landlord"), "the payee should be recorded")
+			Expect(forecast.Days[1].Entries[0].AmountCents).To(Equal(-7000), "the amount should be expressed in cents")
+			Expect(forecast.Days[1].BalanceCents).To(Equal(3000), "the outflow should be applied")
+			Expect(forecast.Days[1].BelowMinimum).To(BeTrue(), "the balance dips below the minimum")
+
+			Expect(forecast.Days[2].BalanceCents).To(Equal(7000), "the inflow should be applied")
+
+			Expect(forecast.LowestBalanceCents).To(Equal(3000), "the low-water mark should be found")
+			Expect(forecast.LowestBalanceDate).To(Equal(forecast.Days[1].Date), "the date of the low-water mark should be recorded")
+		})
+
+		It("applies every occurrence of a repeating bill within the forecast", func() {
+			accountID := "forecast-account"
+			today := civil.Today(clock)
+
+			forecast, err := math.ForecastAccount(
+				clock,
+				offrampynab.Account{
+					Id:      accountID,
+					Name:    "Checking",
+					Balance: 100000, // 100.00 USD
+				},
+				[]offrampynab.ScheduledTransactionDetail{
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: accountID,
+							Amount:    -20000, // 20.00 USD
+							DateFirst: today.AddDays(1).String(),
+							DateNext:  today.AddDays(1).String(),
+							Frequency: "weekly",
+						},
+						PayeeName: "Gym",
+					},
+				},
+				nil,
+				today.AddDays(20),
+			)
+
+			Expect(err).ToNot(HaveOccurred(), "forecasting the account should not fail")
+			Expect(forecast.Days).To(HaveLen(21), "there should be a day for today and the twenty following days")
+
+			var billDates []string
+			for _, day := range forecast.Days {
+				for _, entry := range day.Entries {
+					Expect(entry.Payee).To(Equal("Gym"), "only the weekly bill should be in the ledger")
+					Expect(entry.AmountCents).To(Equal(-2000), "each occurrence should be for the bill's amount")
+					billDates = append(billDates, day.Date)
+				}
+			}
+			Expect(billDates).To(Equal([]string{
+				today.AddDays(1).String(),
+				today.AddDays(8).String(),
+				today.AddDays(15).String(),
+			}), "the bill should be applied on its next date and each week after it")
+
+			Expect(forecast.Days[1].BalanceCents).To(Equal(8000), "the first occurrence should be applied")
+			Expect(forecast.Days[8].BalanceCents).To(Equal(6000), "the second occurrence should be applied")
+			Expect(forecast.Days[15].BalanceCents).To(Equal(4000), "the third occurrence should be applied")
+			Expect(forecast.Days[20].BalanceCents).To(Equal(4000), "no further occurrence falls within the forecast")
+
+			Expect(forecast.LowestBalanceCents).To(Equal(4000), "the low-water mark should reflect every occurrence")
+			Expect(forecast.LowestBalanceDate).To(Equal(today.AddDays(15).String()), "the low-water mark should be on the last occurrence")
+		})
+	})
+})
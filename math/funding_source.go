@@ -0,0 +1,86 @@
+package math
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// FundingSource determines how much an offramp account is to be funded.
+type FundingSource interface {
+	// CalculateFunding returns the funding needed by the given account.
+	CalculateFunding(ctx context.Context, account offrampynab.Account) (*offrampynab.OutboundTransactionBalance, error)
+}
+
+// ScheduledFundingSource funds an account with its outbound transactions within a window,
+// as calculated by CalculateOutboundTransactions.
+type ScheduledFundingSource struct {
+	AccountIDs            []string                    // the IDs of all of the offramp accounts, between which transfers are netted
+	FlagColorsByAccountID map[string]*FlagColorFilter // the flag colors allowed for each account
+	Rules                 *TransactionRules           // the rules that include or exclude transactions
+	Transactions          []offrampynab.ScheduledTransactionDetail
+	StartDate             civil.Date
+	EndDate               civil.Date
+
+	balances map[string]*offrampynab.OutboundTransactionBalance // the outbound balances of all of the accounts, calculated on first use
+}
+
+var _ FundingSource = (*ScheduledFundingSource)(nil)
+
+// CalculateFunding returns the sum of the account's outbound transactions within the window.
+func (s *ScheduledFundingSource) CalculateFunding(ctx context.Context, account offrampynab.Account) (*offrampynab.OutboundTransactionBalance, error) {
+	// Transfers are netted across all of the accounts, so calculate all of their balances at once
+	if s.balances == nil {
+		balances, err := CalculateOutboundTransactions(ctx, s.AccountIDs, s.FlagColorsByAccountID, s.Rules, s.Transactions, s.StartDate, s.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate outbound transactions: %w", err)
+		}
+		s.balances = balances
+	}
+
+	balance, hasBalance := s.balances[account.Id]
+	if !hasBalance {
+		return &offrampynab.OutboundTransactionBalance{}, nil
+	}
+
+	return balance, nil
+}
+
+// CategoryFundingSource funds an account with the amount available in the month's budget for the given categories,
+// less what the account already holds.
+type CategoryFundingSource struct {
+	Categories []offrampynab.Category // the categories, with their balances for the month, whose available amounts are to be moved into the account
+}
+
+var _ FundingSource = (*CategoryFundingSource)(nil)
+
+// CalculateFunding returns the amount available in the categories less the account's balance, to no less than zero.
+func (c *CategoryFundingSource) CalculateFunding(ctx context.Context, account offrampynab.Account) (*offrampynab.OutboundTransactionBalance, error) {
+	logger := logging.FromContext(ctx).With("account", account.Name)
+
+	available := 0
+	for _, category := range c.Categories {
+		// overspent categories have nothing to move
+		categoryAvailable := max(category.Balance, 0)
+
+		dollars, cents := toDollarsAndCents(categoryAvailable)
+		logger.DebugContext(ctx, "counting category available amount",
+			"category", category.Name,
+			"amount", currency.FormatDollarsAndCents(dollars, cents))
+
+		available += categoryAvailable
+	}
+
+	funding := max(available-max(account.Balance, 0), 0)
+
+	dollars, cents := toDollarsAndCents(funding)
+
+	return &offrampynab.OutboundTransactionBalance{
+		Dollars: dollars,
+		Cents:   cents,
+	}, nil
+}
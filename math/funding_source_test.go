@@ -0,0 +1,81 @@
+package math_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("FundingSource", func() {
+	Context("ScheduledFundingSource", func() {
+		It("funds the account's outbound transactions within the window", func() {
+			accountID := "checking"
+			date := civil.Date{Year: 2025, Month: time.July, Day: 1}
+
+			fundingSource := &math.ScheduledFundingSource{
+				AccountIDs: []string{accountID, "other"},
+				Transactions: []offrampynab.ScheduledTransactionDetail{
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							AccountId: accountID,
+							Amount:    -42000,
+							DateNext:  date.String(),
+						},
+					},
+				},
+				StartDate: date,
+				EndDate:   date,
+			}
+
+			funding, err := fundingSource.CalculateFunding(context.Background(), offrampynab.Account{Id: accountID})
+			Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+			Expect(funding.ToCents()).To(Equal(4200), "the outbound transaction should be funded")
+
+			otherFunding, err := fundingSource.CalculateFunding(context.Background(), offrampynab.Account{Id: "other"})
+			Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+			Expect(otherFunding.ToCents()).To(BeZero(), "an account without transactions should not be funded")
+		})
+	})
+
+	Context("CategoryFundingSource", func() {
+		var fundingSource *math.CategoryFundingSource
+
+		BeforeEach(func() {
+			fundingSource = &math.CategoryFundingSource{
+				Categories: []offrampynab.Category{
+					{Name: "Groceries", Balance: 300000},
+					{Name: "Dining Out", Balance: 125500},
+					{Name: "Clothing", Balance: -20000}, // overspent
+				},
+			}
+		})
+
+		It("funds the available amounts less what the account holds", func() {
+			funding, err := fundingSource.CalculateFunding(context.Background(), offrampynab.Account{Balance: 100000})
+			Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+			Expect(funding.ToCents()).To(Equal(32550), "the $425.50 available less the $100.00 held should be funded")
+		})
+
+		When("the account already holds the available amounts", func() {
+			It("does not fund the account", func() {
+				funding, err := fundingSource.CalculateFunding(context.Background(), offrampynab.Account{Balance: 500000})
+				Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+				Expect(funding.ToCents()).To(BeZero(), "no funding should be needed")
+			})
+		})
+
+		When("the account is overdrawn", func() {
+			It("funds only the available amounts", func() {
+				funding, err := fundingSource.CalculateFunding(context.Background(), offrampynab.Account{Balance: -5000})
+				Expect(err).ToNot(HaveOccurred(), "calculating the funding should not fail")
+				Expect(funding.ToCents()).To(Equal(42550), "an overdraft should not be treated as available cash")
+			})
+		})
+	})
+})
@@ -2,11 +2,17 @@ package math_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 )
 
+// clock pins today for the tests whose transactions are scheduled relative to today.
+var clock = civil.FixedClock{Time: time.Date(2025, time.March, 12, 9, 30, 0, 0, time.UTC)}
+
 func TestMath(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Math Suite")
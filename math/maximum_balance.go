@@ -0,0 +1,51 @@
+package math
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// CalculateMaximumBalanceSweep returns the amount that should be swept out of the given account so that,
+// after all of the given transactions between today (according to the given clock) and the given end date (inclusive) and the given funding (expressed in cents)
+// have been applied, its balance does not exceed the given maximum account balance (expressed in cents).
+func CalculateMaximumBalanceSweep(
+	ctx context.Context,
+	clock civil.Clock,
+	account offrampynab.Account,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	fundingCents int,
+	maximumAccountBalanceCents int,
+	endDate civil.Date,
+) (*offrampynab.BalanceSweep, error) {
+	filteredTransactions := filterToAccountIDs(transactions, []string{account.Id})
+
+	effectiveBalanceThrough, err := CalculateEffectiveBalanceThrough(clock, account.Balance, filteredTransactions, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate effective balance through: %w", err)
+	}
+
+	effectiveBalanceDollars, effectiveBalanceRemainingCents := toDollarsAndCents(effectiveBalanceThrough)
+	projectedBalanceCents := effectiveBalanceDollars*100 + effectiveBalanceRemainingCents + fundingCents
+
+	logging.FromContext(ctx).DebugContext(ctx, "projected account balance against maximum balance",
+		"account", account.Name,
+		"projected_balance", currency.FormatCents(projectedBalanceCents),
+		"maximum_balance", currency.FormatCents(maximumAccountBalanceCents))
+
+	if projectedBalanceCents <= maximumAccountBalanceCents {
+		return &offrampynab.BalanceSweep{}, nil
+	}
+
+	excessCents := projectedBalanceCents - maximumAccountBalanceCents
+	excessRemainingCents := excessCents % 100
+
+	return &offrampynab.BalanceSweep{
+		Dollars: (excessCents - excessRemainingCents) / 100,
+		Cents:   excessRemainingCents,
+	}, nil
+}
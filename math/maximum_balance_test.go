@@ -0,0 +1,86 @@
+package math_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("MaximumBalance", func() {
+	Context("CalculateMaximumBalanceSweep", func() {
+		var accountID string
+		var transactions []offrampynab.ScheduledTransactionDetail
+		var today civil.Date
+
+		BeforeEach(func() {
+			accountID = "0b8a4f3e-10f1-4a4c-9a59-3d5b2c1e8f00"
+			today = civil.Today(clock)
+
+			transactions = []offrampynab.ScheduledTransactionDetail{
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -50000, // 50.00 USD
+						DateNext:  today.String(),
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: "other-account",
+						Amount:    -900000,
+						DateNext:  today.String(),
+					},
+				},
+			}
+		})
+
+		When("the projected balance exceeds the maximum balance", func() {
+			It("calculates the excess to be swept", func() {
+				account := offrampynab.Account{
+					Id:      accountID,
+					Balance: 500000, // 500.00 USD
+				}
+
+				sweep, err := math.CalculateMaximumBalanceSweep(
+					context.Background(),
+					clock,
+					account,
+					transactions,
+					2500,  // 25.00 USD being funded this run
+					30000, // 300.00 USD
+					today.AddDays(1),
+				)
+
+				Expect(err).ToNot(HaveOccurred(), "calculating the sweep should not fail")
+				Expect(sweep.ToCents()).To(Equal(17500), "the sweep should be the projected balance ($475.00) less the maximum balance ($300.00)")
+			})
+		})
+
+		When("the projected balance does not exceed the maximum balance", func() {
+			It("returns no sweep", func() {
+				account := offrampynab.Account{
+					Id:      accountID,
+					Balance: 100000, // 100.00 USD
+				}
+
+				sweep, err := math.CalculateMaximumBalanceSweep(
+					context.Background(),
+					clock,
+					account,
+					transactions,
+					0,
+					50000, // 500.00 USD
+					today.AddDays(1),
+				)
+
+				Expect(err).ToNot(HaveOccurred(), "calculating the sweep should not fail")
+				Expect(sweep.ToCents()).To(Equal(0), "there should be no sweep when the balance stays under the maximum")
+			})
+		})
+	})
+})
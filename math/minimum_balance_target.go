@@ -0,0 +1,138 @@
+package math
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// MinimumBalanceRules describes the rules that determine the minimum balance to be maintained in an account.
+// When more than one rule is given, the rule producing the highest minimum balance is used.
+type MinimumBalanceRules struct {
+	FixedCents            *int     // if non-nil, a fixed minimum balance, expressed in cents
+	OutflowPercentage     *float64 // if non-nil, a minimum balance that is this percentage of the window's projected outflow
+	LargestBill           bool     // if true, a minimum balance equal to the largest single bill within the window
+	CoverDaysBeyondWindow int      // if positive, a minimum balance that covers the outflow of this many days after the window, including later occurrences of repeating bills
+}
+
+// MinimumBalanceTarget is the minimum balance that an account should maintain.
+type MinimumBalanceTarget struct {
+	Cents int    // the minimum balance, expressed in cents
+	Rule  string // a description of the rule that produced this minimum balance
+}
+
+// CalculateMinimumBalanceTarget evaluates the given rules against the given transactions for the given account
+// within the given start and end dates (inclusive) and returns the highest resulting minimum balance.
+// If the rules are nil or specify no rules, nil is returned.
+func CalculateMinimumBalanceTarget(
+	ctx context.Context,
+	accountID string,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	rules *MinimumBalanceRules,
+	startDate civil.Date,
+	endDate civil.Date,
+) (*MinimumBalanceTarget, error) {
+	if rules == nil {
+		return nil, nil
+	}
+
+	accountOutflows := filterToOutboundOnly(filterToAccountIDs(transactions, []string{accountID}))
+
+	windowOutflows, err := filterTransactionsByDateRange(accountOutflows, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transactions to window: %w", err)
+	}
+
+	var candidates []*MinimumBalanceTarget
+
+	if rules.FixedCents != nil {
+		candidates = append(candidates, &MinimumBalanceTarget{
+			Cents: *rules.FixedCents,
+			Rule:  "fixed minimum balance of " + currency.FormatCents(*rules.FixedCents),
+		})
+	}
+
+	if rules.OutflowPercentage != nil {
+		windowOutflowCents := outflowCents(windowOutflows)
+		percentageCents := int(math.Ceil(float64(windowOutflowCents) * *rules.OutflowPercentage / 100))
+		candidates = append(candidates, &MinimumBalanceTarget{
+			Cents: percentageCents,
+			Rule:  fmt.Sprintf("%g%% of projected outflow of %s", *rules.OutflowPercentage, currency.FormatCents(windowOutflowCents)),
+		})
+	}
+
+	if rules.LargestBill {
+		largestBillCents := 0
+		largestBillPayee := ""
+		for _, transaction := range windowOutflows {
+			billCents := outflowCents([]offrampynab.ScheduledTransactionDetail{transaction})
+			if billCents > largestBillCents {
+				largestBillCents = billCents
+				largestBillPayee = transaction.PayeeName
+			}
+		}
+
+		rule := "largest bill in window"
+		if largestBillPayee != "" {
+			rule = fmt.Sprintf("largest bill in window (%s)", largestBillPayee)
+		}
+
+		candidates = append(candidates, &MinimumBalanceTarget{
+			Cents: largestBillCents,
+			Rule:  rule,
+		})
+	}
+
+	if rules.CoverDaysBeyondWindow > 0 {
+		dayAfterEnd := endDate.AddDays(1)
+		coverageEnd := endDate.AddDays(rules.CoverDaysBeyondWindow)
+
+		// the days beyond the window can include later occurrences of bills whose next occurrence is in or before it
+		projectedOutflows, err := projectOccurrences(accountOutflows, coverageEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to project occurrences of transactions beyond window: %w", err)
+		}
+
+		coveredOutflows, err := filterTransactionsByDateRange(projectedOutflows, dayAfterEnd, coverageEnd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter transactions to days beyond window: %w", err)
+		}
+
+		candidates = append(candidates, &MinimumBalanceTarget{
+			Cents: outflowCents(coveredOutflows),
+			Rule:  fmt.Sprintf("outflow of the %d day(s) after the window", rules.CoverDaysBeyondWindow),
+		})
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	logger := logging.FromContext(ctx)
+
+	selected := candidates[0]
+	for _, candidate := range candidates {
+		logger.DebugContext(ctx, "evaluated minimum balance rule",
+			"account_id", accountID,
+			"rule", candidate.Rule,
+			"minimum_balance", currency.FormatCents(candidate.Cents))
+
+		if candidate.Cents > selected.Cents {
+			selected = candidate
+		}
+	}
+
+	return selected, nil
+}
+
+// outflowCents sums the given outflows and expresses them as a positive number of cents.
+func outflowCents(transactions []offrampynab.ScheduledTransactionDetail) int {
+	dollars, cents := toDollarsAndCents(int(math.Abs(float64(sumTransactions(transactions)))))
+
+	return dollars*100 + cents
+}
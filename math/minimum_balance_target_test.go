@@ -0,0 +1,179 @@
+package math_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("MinimumBalanceTarget", func() {
+	Context("CalculateMinimumBalanceTarget", func() {
+		var ctx context.Context
+		var accountID string
+		var startDate civil.Date
+		var endDate civil.Date
+		var transactions []offrampynab.ScheduledTransactionDetail
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			accountID = "target-account"
+
+			startDate, _ = civil.ParseDate("2024-03-01")
+			endDate, _ = civil.ParseDate("2024-03-07")
+
+			transactions = []offrampynab.ScheduledTransactionDetail{
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -100000, // 100.00 USD
+						DateNext:  "2024-03-02",
+					},
+					PayeeName: "Electric Company",
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -300000, // 300.00 USD
+						DateNext:  "2024-03-05",
+					},
+					PayeeName: "Landlord",
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    50000, // an inflow, which is not a bill
+						DateNext:  "2024-03-06",
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -75000, // 75.00 USD, two days after the window
+						DateNext:  "2024-03-09",
+					},
+				},
+				{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: "other-account",
+						Amount:    -999000,
+						DateNext:  "2024-03-03",
+					},
+				},
+			}
+		})
+
+		When("no rules are given", func() {
+			It("returns no target", func() {
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, transactions, nil, startDate, endDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target).To(BeNil(), "there should be no target without rules")
+			})
+		})
+
+		It("calculates a percentage of the window's projected outflow", func() {
+			percentage := 10.0
+			target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, transactions, &math.MinimumBalanceRules{
+				OutflowPercentage: &percentage,
+			}, startDate, endDate)
+			Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+			Expect(target.Cents).To(Equal(4000), "the target should be 10% of the $400.00 outflow in the window")
+			Expect(target.Rule).To(ContainSubstring("10%"), "the rule should describe the percentage")
+		})
+
+		It("calculates the largest bill in the window", func() {
+			target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, transactions, &math.MinimumBalanceRules{
+				LargestBill: true,
+			}, startDate, endDate)
+			Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+			Expect(target.Cents).To(Equal(30000), "the target should be the largest bill in the window")
+			Expect(target.Rule).To(ContainSubstring("Landlord"), "the rule should name the largest bill")
+		})
+
+		It("calculates the outflow of the days beyond the window", func() {
+			target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, transactions, &math.MinimumBalanceRules{
+				CoverDaysBeyondWindow: 2,
+			}, startDate, endDate)
+			Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+			Expect(target.Cents).To(Equal(7500), "the target should cover the bill two days after the window")
+		})
+
+		When("bills repeat", func() {
+			repeatingBill := func(dateNext string, frequency string) offrampynab.ScheduledTransactionDetail {
+				return offrampynab.ScheduledTransactionDetail{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+						AccountId: accountID,
+						Amount:    -20000, // 20.00 USD
+						DateFirst: dateNext,
+						DateNext:  dateNext,
+						Frequency: frequency,
+					},
+					PayeeName: "Repeating Bill",
+				}
+			}
+
+			It("covers the later occurrences of a bill whose next occurrence is in the window", func() {
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, []offrampynab.ScheduledTransactionDetail{
+					repeatingBill("2024-03-02", "weekly"),
+				}, &math.MinimumBalanceRules{
+					CoverDaysBeyondWindow: 14,
+				}, startDate, endDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target.Cents).To(Equal(4000), "the target should cover the weekly bill on 2024-03-09 and 2024-03-16")
+			})
+
+			It("moves a monthly bill to the last day of a shorter month", func() {
+				windowStartDate, _ := civil.ParseDate("2024-01-25")
+				windowEndDate, _ := civil.ParseDate("2024-01-31")
+
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, []offrampynab.ScheduledTransactionDetail{
+					repeatingBill("2024-01-31", "monthly"),
+				}, &math.MinimumBalanceRules{
+					CoverDaysBeyondWindow: 29,
+				}, windowStartDate, windowEndDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target.Cents).To(Equal(2000), "the target should cover the monthly bill on 2024-02-29")
+			})
+
+			It("does not cover occurrences beyond the covered days", func() {
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, []offrampynab.ScheduledTransactionDetail{
+					repeatingBill("2024-03-02", "monthly"),
+				}, &math.MinimumBalanceRules{
+					CoverDaysBeyondWindow: 14,
+				}, startDate, endDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target.Cents).To(BeZero(), "the next occurrence of the monthly bill is after the covered days")
+			})
+
+			It("does not project a bill that repeats twice a month beyond its next occurrence", func() {
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, []offrampynab.ScheduledTransactionDetail{
+					repeatingBill("2024-03-02", "twiceAMonth"),
+				}, &math.MinimumBalanceRules{
+					CoverDaysBeyondWindow: 30,
+				}, startDate, endDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target.Cents).To(BeZero(), "YNAB does not report the other day of the month on which the bill repeats")
+			})
+		})
+
+		When("multiple rules are given", func() {
+			It("uses the rule with the highest minimum balance", func() {
+				fixedCents := 20000
+				percentage := 10.0
+				target, err := math.CalculateMinimumBalanceTarget(ctx, accountID, transactions, &math.MinimumBalanceRules{
+					FixedCents:            &fixedCents,
+					OutflowPercentage:     &percentage,
+					LargestBill:           true,
+					CoverDaysBeyondWindow: 2,
+				}, startDate, endDate)
+				Expect(err).ToNot(HaveOccurred(), "calculating the target should not fail")
+				Expect(target.Cents).To(Equal(30000), "the largest bill should produce the highest target")
+				Expect(target.Rule).To(ContainSubstring("largest bill"), "the rule that drove the target should be identified")
+			})
+		})
+	})
+})
@@ -1,23 +1,31 @@
 package math
 
 import (
+	"context"
 	"fmt"
 	"math"
-	"time"
-
-	"github.com/davidsteinsland/ynab-go/ynab"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
 	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
 // CalculateOutboundTransactions will pull, from the given scheduled transactions, all outbound transactions that are happening
-// within the given start and end date/time (inclusive) for the given account IDs.
+// within the given start and end dates (inclusive) for the given account IDs.
+// Transactions whose flag color is not allowed for their account, or excluded by the given transaction rules, are not counted.
+// Transfers between two of the given accounts are not counted as outbound transactions; instead, the net amount each account sends
+// to the others is added to its balance, and the net amount it receives from the others is deducted from it (to no less than zero),
+// so that only money leaving the set of accounts is funded. Each transfer is expected to be given once, as YNAB lists scheduled transfers.
+// Each transaction counted towards an account's balance is written to the context's logger at debug level.
 func CalculateOutboundTransactions(
+	ctx context.Context,
 	accountIDs []string,
-	excludedColorsByAccountID map[string][]string,
-	transactions []ynab.ScheduledTransactionDetail,
-	startDate time.Time,
-	endDate time.Time,
+	flagColorsByAccountID map[string]*FlagColorFilter,
+	rules *TransactionRules,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	startDate civil.Date,
+	endDate civil.Date,
 ) (map[string]*offrampynab.OutboundTransactionBalance, error) {
 	filteredByAccount := filterToAccountIDs(transactions, accountIDs)
 
@@ -26,16 +34,42 @@ func CalculateOutboundTransactions(
 		return nil, fmt.Errorf("failed to filter transactions by date range: %w", err)
 	}
 
-	outboundOnly := filterToOutboundOnly(filteredByDate)
+	onlyAllowedFlags := filterToOnlyAllowedFlags(filteredByDate, flagColorsByAccountID)
+
+	onlyAllowedByRules := filterByRules(onlyAllowedFlags, rules)
+
+	external, internalTransfers := splitInternalTransfers(accountIDs, onlyAllowedByRules)
 
-	onlyAllowedFlags := filterToOnlyAllowedFlags(outboundOnly, excludedColorsByAccountID)
+	outboundOnly := filterToOutboundOnly(external)
 
-	grouped := groupTransactionsByAccountID(accountIDs, onlyAllowedFlags)
+	grouped := groupTransactionsByAccountID(accountIDs, outboundOnly)
+
+	logger := logging.FromContext(ctx)
+	for _, transaction := range outboundOnly {
+		dollars, cents := toDollarsAndCents(transaction.Amount)
+		logger.DebugContext(ctx, "counting outbound transaction",
+			"account_id", transaction.AccountId,
+			"payee", transaction.PayeeName,
+			"amount", currency.FormatDollarsAndCents(dollars, cents),
+			"date", transaction.DateNext)
+	}
+
+	for _, transfer := range internalTransfers {
+		dollars, cents := toDollarsAndCents(transfer.Amount)
+		logger.DebugContext(ctx, "netting transfer between offramp accounts",
+			"account_id", transfer.AccountId,
+			"transfer_account_id", *transfer.TransferAccountId,
+			"amount", currency.FormatDollarsAndCents(dollars, cents),
+			"date", transfer.DateNext)
+	}
+
+	netTransfersByAccountID := netInternalTransfers(internalTransfers)
 
 	balances := make(map[string]*offrampynab.OutboundTransactionBalance)
 	for accountID, accountTransactions := range grouped {
-		sum := sumTransactions(accountTransactions)
-		sum = int(math.Abs(float64(sum)))
+		// money received from the other accounts reduces what must be funded, and money sent to them increases it
+		sum := sumTransactions(accountTransactions) + netTransfersByAccountID[accountID]
+		sum = int(math.Abs(float64(min(sum, 0))))
 		dollars, cents := toDollarsAndCents(sum)
 		balances[accountID] = &offrampynab.OutboundTransactionBalance{
 			Dollars: dollars,
@@ -46,39 +80,22 @@ func CalculateOutboundTransactions(
 	return balances, nil
 }
 
-func filterToOnlyAllowedFlags(transactions []ynab.ScheduledTransactionDetail, excludedColorsByAccountID map[string][]string) []ynab.ScheduledTransactionDetail {
-	if excludedColorsByAccountID == nil {
-		return transactions
-	}
-
-	trimmedDown := make([]ynab.ScheduledTransactionDetail, len(transactions))
-	copy(trimmedDown, transactions)
+func filterToOnlyAllowedFlags(transactions []offrampynab.ScheduledTransactionDetail, flagColorsByAccountID map[string]*FlagColorFilter) []offrampynab.ScheduledTransactionDetail {
+	included := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
 
-	for i := len(trimmedDown) - 1; i >= 0; i-- {
-		transaction := trimmedDown[i]
-		if transaction.FlagColor == nil {
-			continue
-		}
-
-		excludedColors, hasExclusions := excludedColorsByAccountID[transaction.AccountId]
-		if !hasExclusions {
+	for _, transaction := range transactions {
+		if excluded, _ := flagColorsByAccountID[transaction.AccountId].Excludes(transaction); excluded {
 			continue
 		}
 
-		for _, excludedColor := range excludedColors {
-			if excludedColor == *transaction.FlagColor {
-				trimmedDown = append(trimmedDown[:i], trimmedDown[i+1:]...)
-
-				break
-			}
-		}
+		included = append(included, transaction)
 	}
 
-	return trimmedDown
+	return included
 }
 
-func filterToOutboundOnly(transactions []ynab.ScheduledTransactionDetail) []ynab.ScheduledTransactionDetail {
-	included := make([]ynab.ScheduledTransactionDetail, 0, len(transactions))
+func filterToOutboundOnly(transactions []offrampynab.ScheduledTransactionDetail) []offrampynab.ScheduledTransactionDetail {
+	included := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
 
 	for _, transaction := range transactions {
 		if transaction.Amount >= 0 {
@@ -91,8 +108,8 @@ func filterToOutboundOnly(transactions []ynab.ScheduledTransactionDetail) []ynab
 	return included
 }
 
-func filterTransactionsByDateRange(transactions []ynab.ScheduledTransactionDetail, startDate, endDate time.Time) ([]ynab.ScheduledTransactionDetail, error) {
-	included := make([]ynab.ScheduledTransactionDetail, 0, len(transactions))
+func filterTransactionsByDateRange(transactions []offrampynab.ScheduledTransactionDetail, startDate, endDate civil.Date) ([]offrampynab.ScheduledTransactionDetail, error) {
+	included := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
 
 	for _, transaction := range transactions {
 		isAfterInclusive, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, startDate)
@@ -119,10 +136,10 @@ func filterTransactionsByDateRange(transactions []ynab.ScheduledTransactionDetai
 	return included, nil
 }
 
-func groupTransactionsByAccountID(accountIDs []string, transactions []ynab.ScheduledTransactionDetail) map[string][]ynab.ScheduledTransactionDetail {
-	grouped := make(map[string][]ynab.ScheduledTransactionDetail)
+func groupTransactionsByAccountID(accountIDs []string, transactions []offrampynab.ScheduledTransactionDetail) map[string][]offrampynab.ScheduledTransactionDetail {
+	grouped := make(map[string][]offrampynab.ScheduledTransactionDetail)
 	for _, accountID := range accountIDs {
-		grouped[accountID] = make([]ynab.ScheduledTransactionDetail, 0)
+		grouped[accountID] = make([]offrampynab.ScheduledTransactionDetail, 0)
 	}
 
 	for _, transaction := range transactions {
@@ -132,7 +149,7 @@ func groupTransactionsByAccountID(accountIDs []string, transactions []ynab.Sched
 	return grouped
 }
 
-func sumTransactions(transactions []ynab.ScheduledTransactionDetail) int {
+func sumTransactions(transactions []offrampynab.ScheduledTransactionDetail) int {
 	summed := 0
 	for _, transaction := range transactions {
 		summed += transaction.Amount
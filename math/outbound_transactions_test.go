@@ -1,13 +1,14 @@
 package math_test
 
 import (
-	"time"
+	"context"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
 var _ = Describe("OutboundTransactions", func() {
@@ -16,34 +17,34 @@ var _ = Describe("OutboundTransactions", func() {
 			accountID0 := "account0"
 			accountID1 := "account1"
 
-			startDate, _ := time.Parse(time.DateOnly, "2020-01-01")
-			endDate, _ := time.Parse(time.DateOnly, "2020-01-03")
+			startDate, _ := civil.ParseDate("2020-01-01")
+			endDate, _ := civil.ParseDate("2020-01-03")
 
-			transactions := []ynab.ScheduledTransactionDetail{
+			transactions := []offrampynab.ScheduledTransactionDetail{
 				{
-					ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 						AccountId: accountID0,
 						Amount:    -1230,
-						DateNext:  startDate.Format(time.DateOnly),
+						DateNext:  startDate.String(),
 					},
 				},
 				{
-					ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 						AccountId: accountID1,
 						Amount:    -456780,
-						DateNext:  endDate.Format(time.DateOnly),
+						DateNext:  endDate.String(),
 					},
 				},
 				{
-					ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+					ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 						AccountId: accountID0,
 						Amount:    -560,
-						DateNext:  startDate.Add(24 * time.Hour).Format(time.DateOnly),
+						DateNext:  startDate.AddDays(1).String(),
 					},
 				},
 			}
 
-			grouped, err := math.CalculateOutboundTransactions([]string{accountID0, accountID1}, nil, transactions, startDate, endDate)
+			grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID0, accountID1}, nil, nil, transactions, startDate, endDate)
 			Expect(err).ToNot(HaveOccurred(), "the calculation should not fail")
 			Expect(grouped).To(And(
 				HaveLen(2),
@@ -61,25 +62,25 @@ var _ = Describe("OutboundTransactions", func() {
 		When("the transactions include non-outbound transactions", func() {
 			It("filters out those transactions", func() {
 				accountID := "not-all-outbound"
-				dateRange, _ := time.Parse(time.DateOnly, "2020-01-01")
-				transactions := []ynab.ScheduledTransactionDetail{
+				dateRange, _ := civil.ParseDate("2020-01-01")
+				transactions := []offrampynab.ScheduledTransactionDetail{
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    12300,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -4560,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 				}
 
-				grouped, err := math.CalculateOutboundTransactions([]string{accountID}, nil, transactions, dateRange, dateRange)
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, nil, transactions, dateRange, dateRange)
 				Expect(err).ToNot(HaveOccurred(), "calcuating the transactions should not fail")
 				Expect(grouped).To(HaveKey(accountID), "the account should be returned")
 				Expect(grouped[accountID].ToCents()).To(Equal(456), "the balance should not include the inbound transaction")
@@ -89,25 +90,25 @@ var _ = Describe("OutboundTransactions", func() {
 		When("the transactions include transactions for accounts not in the given list", func() {
 			It("filters out those transactions", func() {
 				accountID := "actually-desired-account"
-				dateRange, _ := time.Parse(time.DateOnly, "2021-02-01")
-				transactions := []ynab.ScheduledTransactionDetail{
+				dateRange, _ := civil.ParseDate("2021-02-01")
+				transactions := []offrampynab.ScheduledTransactionDetail{
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -1230,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: "excluded",
 							Amount:    -4560,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 				}
 
-				grouped, err := math.CalculateOutboundTransactions([]string{accountID}, nil, transactions, dateRange, dateRange)
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, nil, transactions, dateRange, dateRange)
 				Expect(err).ToNot(HaveOccurred(), "calulating the transactions should not fail")
 				Expect(grouped).To(And(HaveLen(1), HaveKey(accountID)), "only the desired account should be in the returned amounts")
 			})
@@ -116,25 +117,25 @@ var _ = Describe("OutboundTransactions", func() {
 		When("there are transactions before the start date", func() {
 			It("filters out those transactions", func() {
 				accountID := "some-before-start"
-				dateRange, _ := time.Parse(time.DateOnly, "2020-01-01")
-				transactions := []ynab.ScheduledTransactionDetail{
+				dateRange, _ := civil.ParseDate("2020-01-01")
+				transactions := []offrampynab.ScheduledTransactionDetail{
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -1230,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -4560,
-							DateNext:  dateRange.Add(-24 * time.Hour).Format(time.DateOnly),
+							DateNext:  dateRange.AddDays(-1).String(),
 						},
 					},
 				}
 
-				grouped, err := math.CalculateOutboundTransactions([]string{accountID}, nil, transactions, dateRange, dateRange)
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, nil, transactions, dateRange, dateRange)
 				Expect(err).ToNot(HaveOccurred(), "calculating the outbound transactions should not fail")
 				Expect(grouped).To(HaveKey(accountID), "the account should be in the returned transactions")
 				Expect(grouped[accountID].ToCents()).To(Equal(123), "only the amount that fits in the date range should be accepted")
@@ -144,25 +145,25 @@ var _ = Describe("OutboundTransactions", func() {
 		When("there are transactions after the end date", func() {
 			It("filters out those transactions", func() {
 				accountID := "some-before-start"
-				dateRange, _ := time.Parse(time.DateOnly, "2020-01-01")
-				transactions := []ynab.ScheduledTransactionDetail{
+				dateRange, _ := civil.ParseDate("2020-01-01")
+				transactions := []offrampynab.ScheduledTransactionDetail{
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -1230,
-							DateNext:  dateRange.Format(time.DateOnly),
+							DateNext:  dateRange.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID,
 							Amount:    -4560,
-							DateNext:  dateRange.Add(24 * time.Hour).Format(time.DateOnly),
+							DateNext:  dateRange.AddDays(1).String(),
 						},
 					},
 				}
 
-				grouped, err := math.CalculateOutboundTransactions([]string{accountID}, nil, transactions, dateRange, dateRange)
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, nil, transactions, dateRange, dateRange)
 				Expect(err).ToNot(HaveOccurred(), "calculating the outbound transactions should not fail")
 				Expect(grouped).To(HaveKey(accountID), "the account should be in the returned transactions")
 				Expect(grouped[accountID].ToCents()).To(Equal(123), "only the amount that fits in the date range should be accepted")
@@ -176,36 +177,36 @@ var _ = Describe("OutboundTransactions", func() {
 
 				excludedFlagColor := "red"
 
-				startDate, _ := time.Parse(time.DateOnly, "2020-01-01")
-				endDate, _ := time.Parse(time.DateOnly, "2020-01-03")
-				transactions := []ynab.ScheduledTransactionDetail{
+				startDate, _ := civil.ParseDate("2020-01-01")
+				endDate, _ := civil.ParseDate("2020-01-03")
+				transactions := []offrampynab.ScheduledTransactionDetail{
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID0,
 							Amount:    -1230,
-							DateNext:  startDate.Format(time.DateOnly),
+							DateNext:  startDate.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID1,
 							Amount:    -456780,
-							DateNext:  endDate.Format(time.DateOnly),
+							DateNext:  endDate.String(),
 						},
 					},
 					{
-						ScheduledTransactionSummary: ynab.ScheduledTransactionSummary{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
 							AccountId: accountID0,
 							Amount:    -560,
-							DateNext:  startDate.Add(24 * time.Hour).Format(time.DateOnly),
+							DateNext:  startDate.AddDays(1).String(),
 							FlagColor: &excludedFlagColor, // this excludes this tranasction from consideration
 						},
 					},
 				}
 
-				grouped, err := math.CalculateOutboundTransactions([]string{accountID0, accountID1}, map[string][]string{
-					accountID0: {excludedFlagColor},
-				}, transactions, startDate, endDate)
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID0, accountID1}, map[string]*math.FlagColorFilter{
+					accountID0: {Excluded: []string{excludedFlagColor}},
+				}, nil, transactions, startDate, endDate)
 				Expect(err).ToNot(HaveOccurred(), "the calculation should not fail")
 				Expect(grouped).To(And(
 					HaveLen(2),
@@ -0,0 +1,85 @@
+package math
+
+import (
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// DailyBalance is the projected balance of an account at the end of a single day.
+type DailyBalance struct {
+	Date         civil.Date                               // the day
+	Transactions []offrampynab.ScheduledTransactionDetail // the transactions scheduled for the day
+	Balance      int                                      // the balance at the end of the day, expressed as a YNAB transaction amount
+}
+
+// ProjectDailyBalances projects the balance of an account at the end of each day from today (according to the given clock) through the given end date (inclusive),
+// applying the given transactions on the days for which they are scheduled. A repeating transaction is applied on its next date
+// and on each later occurrence through the given end date, as projected by its frequency.
+// Occurrences before today or after the given end date are not applied.
+// Balances are expressed as YNAB transaction amounts, not numbers of cents.
+func ProjectDailyBalances(
+	clock civil.Clock,
+	currentAccountBalance int,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	endDate civil.Date,
+) ([]*DailyBalance, error) {
+	today := civil.Today(clock)
+
+	occurrences, err := projectOccurrences(transactions, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to project occurrences of scheduled transactions: %w", err)
+	}
+
+	transactionsByDate := make(map[civil.Date][]offrampynab.ScheduledTransactionDetail)
+	for _, transaction := range occurrences {
+		isBefore, err := offrampynab.IsScheduledBeforeInclusive(transaction.ScheduledTransactionSummary, today.AddDays(-1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if transaction to payee '%s' is before inclusive: %w", transaction.PayeeName, err)
+		} else if isBefore {
+			continue
+		}
+
+		isAfter, err := offrampynab.IsScheduledAfterInclusive(transaction.ScheduledTransactionSummary, endDate.AddDays(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if transaction to payee '%s' is after inclusive: %w", transaction.PayeeName, err)
+		} else if isAfter {
+			continue
+		}
+
+		// the checks above already ensure that this parses
+		scheduledDate, _ := offrampynab.ParseScheduledDate(transaction.ScheduledTransactionSummary)
+		transactionsByDate[scheduledDate] = append(transactionsByDate[scheduledDate], transaction)
+	}
+
+	var dailyBalances []*DailyBalance
+	runningBalance := currentAccountBalance
+	for day := today; !day.After(endDate); day = day.AddDays(1) {
+		dayTransactions := transactionsByDate[day]
+		runningBalance += sumTransactions(dayTransactions)
+
+		dailyBalances = append(dailyBalances, &DailyBalance{
+			Date:         day,
+			Transactions: dayTransactions,
+			Balance:      runningBalance,
+		})
+	}
+
+	return dailyBalances, nil
+}
+
+// LowestBalance returns the lowest of the given starting balance and the given daily balances,
+// as well as the day on which that balance occurs. If the starting balance is the lowest, the returned day is the zero Date.
+func LowestBalance(startingBalance int, dailyBalances []*DailyBalance) (int, civil.Date) {
+	lowestBalance := startingBalance
+	var lowestDate civil.Date
+	for _, dailyBalance := range dailyBalances {
+		if dailyBalance.Balance < lowestBalance {
+			lowestBalance = dailyBalance.Balance
+			lowestDate = dailyBalance.Date
+		}
+	}
+
+	return lowestBalance, lowestDate
+}
@@ -0,0 +1,62 @@
+package math_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Projection", func() {
+	Context("ProjectDailyBalances", func() {
+		It("projects the running balance for each day through the end date", func() {
+			today := civil.Today(clock)
+
+			dailyBalances, err := math.ProjectDailyBalances(
+				clock,
+				10000,
+				[]offrampynab.ScheduledTransactionDetail{
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							DateNext: today.AddDays(-1).String(),
+							Amount:   -1000, // in the past, so it should not be applied
+						},
+					},
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							DateNext: today.String(),
+							Amount:   -3000,
+						},
+					},
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							DateNext: today.AddDays(2).String(),
+							Amount:   5000,
+						},
+					},
+					{
+						ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+							DateNext: today.AddDays(3).String(),
+							Amount:   -7000, // after the end date, so it should not be applied
+						},
+					},
+				},
+				today.AddDays(2),
+			)
+
+			Expect(err).ToNot(HaveOccurred(), "projecting the balances should not fail")
+			Expect(dailyBalances).To(HaveLen(3), "there should be a balance for today and each of the following two days")
+			Expect(dailyBalances[0].Balance).To(Equal(7000), "today's outflow should be applied")
+			Expect(dailyBalances[0].Transactions).To(HaveLen(1), "today's transaction should be recorded")
+			Expect(dailyBalances[1].Balance).To(Equal(7000), "the balance should carry over to days without transactions")
+			Expect(dailyBalances[1].Transactions).To(BeEmpty(), "there should be no transactions tomorrow")
+			Expect(dailyBalances[2].Balance).To(Equal(12000), "the inflow should be applied on its day")
+
+			lowestBalance, lowestDate := math.LowestBalance(10000, dailyBalances)
+			Expect(lowestBalance).To(Equal(7000), "the lowest balance should be found")
+			Expect(lowestDate).To(Equal(dailyBalances[0].Date), "the date of the lowest balance should be the first day it occurred")
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package math
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// projectOccurrences returns, for each of the given scheduled transactions, its next occurrence and each later occurrence
+// through the given end date (inclusive), projected from its frequency. Each occurrence is a copy of the scheduled transaction
+// whose DateNext is the date of that occurrence.
+//
+// Transactions that repeat twice a month are not projected beyond their next occurrence: YNAB reports only the next of
+// their two days in a month, so the other cannot be known.
+func projectOccurrences(transactions []offrampynab.ScheduledTransactionDetail, endDate civil.Date) ([]offrampynab.ScheduledTransactionDetail, error) {
+	occurrences := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
+	for _, transaction := range transactions {
+		nextDate, err := offrampynab.ParseScheduledDate(transaction.ScheduledTransactionSummary)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next date of transaction to payee '%s': %w", transaction.PayeeName, err)
+		}
+
+		occurrences = append(occurrences, transaction)
+
+		advance := frequencyAdvance(transaction.Frequency)
+		if advance == nil {
+			continue
+		}
+
+		for occurrenceIndex := 1; ; occurrenceIndex++ {
+			occurrenceDate := advance(nextDate, occurrenceIndex)
+			if occurrenceDate.After(endDate) {
+				break
+			}
+
+			occurrence := transaction
+			occurrence.DateNext = occurrenceDate.String()
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences, nil
+}
+
+// frequencyAdvance returns a function that gives the date of the nth occurrence after the given date for the given frequency,
+// or nil if later occurrences of the frequency cannot be projected.
+func frequencyAdvance(frequency string) func(civil.Date, int) civil.Date {
+	everyDays := func(days int) func(civil.Date, int) civil.Date {
+		return func(date civil.Date, occurrence int) civil.Date {
+			return date.AddDays(days * occurrence)
+		}
+	}
+
+	everyMonths := func(months int) func(civil.Date, int) civil.Date {
+		return func(date civil.Date, occurrence int) civil.Date {
+			return addMonths(date, months*occurrence)
+		}
+	}
+
+	switch frequency {
+	case "daily":
+		return everyDays(1)
+	case "weekly":
+		return everyDays(7)
+	case "everyOtherWeek":
+		return everyDays(14)
+	case "every4Weeks":
+		return everyDays(28)
+	case "monthly":
+		return everyMonths(1)
+	case "everyOtherMonth":
+		return everyMonths(2)
+	case "every3Months":
+		return everyMonths(3)
+	case "every4Months":
+		return everyMonths(4)
+	case "twiceAYear":
+		return everyMonths(6)
+	case "yearly":
+		return everyMonths(12)
+	case "everyOtherYear":
+		return everyMonths(24)
+	default:
+		// "never" does not repeat, and "twiceAMonth" cannot be projected
+		return nil
+	}
+}
+
+// addMonths adds the given number of months to the given date. As in YNAB, a day that does not exist in the resulting month
+// is moved back to the last day of that month (e.g., a month after January 31 is February 28 or 29).
+func addMonths(date civil.Date, months int) civil.Date {
+	firstOfMonth := time.Date(date.Year, date.Month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	daysInMonth := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	return civil.Date{
+		Year:  firstOfMonth.Year(),
+		Month: firstOfMonth.Month(),
+		Day:   min(date.Day, daysInMonth),
+	}
+}
@@ -1,10 +1,10 @@
 package math
 
-import "github.com/davidsteinsland/ynab-go/ynab"
+import offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 
 // filterToAccountIDs will filter the given transactions to only include those for the given account IDs.
-func filterToAccountIDs(transactions []ynab.ScheduledTransactionDetail, accountIDs []string) []ynab.ScheduledTransactionDetail {
-	included := make([]ynab.ScheduledTransactionDetail, 0, len(transactions))
+func filterToAccountIDs(transactions []offrampynab.ScheduledTransactionDetail, accountIDs []string) []offrampynab.ScheduledTransactionDetail {
+	included := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
 
 	for _, transaction := range transactions {
 		include := false
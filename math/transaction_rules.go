@@ -0,0 +1,149 @@
+package math
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// TransactionRule describes transactions that are to be included in or excluded from calculations.
+// A transaction matches a rule if it meets all of the rule's conditions; nil conditions are not evaluated.
+type TransactionRule struct {
+	Description        string         // how the rule is identified when explaining why a transaction was excluded
+	Exclude            bool           // true if matched transactions are excluded; false if they are included
+	Payee              *string        // the name of the payee, matched without regard to case
+	PayeePattern       *regexp.Regexp // a pattern that the payee name must match
+	Category           *string        // the name of the category, matched without regard to case
+	CategoryGroup      *string        // the name of the category group, matched without regard to case
+	MemoContains       *string        // text that the memo must contain, matched without regard to case
+	MinimumAmountCents *int           // the smallest amount, regardless of sign, of a matched transaction
+	MaximumAmountCents *int           // the largest amount, regardless of sign, of a matched transaction
+	Transfer           *bool          // whether a matched transaction must (true) or must not (false) be a transfer
+}
+
+// TransactionRules are the rules evaluated against the transactions of each account.
+type TransactionRules struct {
+	RulesByAccountID               map[string][]*TransactionRule // the rules for each account, in the order in which they are evaluated
+	CategoryGroupNamesByCategoryID map[string]string             // the name of the group of each category, used to evaluate category group conditions
+}
+
+// TransactionExclusion describes a transaction that was excluded from calculations and why.
+type TransactionExclusion struct {
+	Transaction offrampynab.ScheduledTransactionDetail
+	Reason      string
+}
+
+// Evaluate returns the first of the rules for the transaction's account that the transaction matches, in order.
+// If the transaction matches no rule, nil is returned and the transaction is included.
+func (t *TransactionRules) Evaluate(transaction offrampynab.ScheduledTransactionDetail) *TransactionRule {
+	if t == nil {
+		return nil
+	}
+
+	for _, rule := range t.RulesByAccountID[transaction.AccountId] {
+		if rule.matches(transaction, t.CategoryGroupNamesByCategoryID) {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func (r *TransactionRule) matches(transaction offrampynab.ScheduledTransactionDetail, categoryGroupNamesByCategoryID map[string]string) bool {
+	if r.Payee != nil && !strings.EqualFold(*r.Payee, transaction.PayeeName) {
+		return false
+	}
+
+	if r.PayeePattern != nil && !r.PayeePattern.MatchString(transaction.PayeeName) {
+		return false
+	}
+
+	if r.Category != nil && !strings.EqualFold(*r.Category, transaction.CategoryName) {
+		return false
+	}
+
+	if r.CategoryGroup != nil {
+		if transaction.CategoryId == nil || !strings.EqualFold(*r.CategoryGroup, categoryGroupNamesByCategoryID[*transaction.CategoryId]) {
+			return false
+		}
+	}
+
+	if r.MemoContains != nil {
+		if transaction.Memo == nil || !strings.Contains(strings.ToLower(*transaction.Memo), strings.ToLower(*r.MemoContains)) {
+			return false
+		}
+	}
+
+	amountCents := toCents(transaction.Amount)
+	if amountCents < 0 {
+		amountCents = -amountCents
+	}
+
+	if r.MinimumAmountCents != nil && amountCents < *r.MinimumAmountCents {
+		return false
+	}
+
+	if r.MaximumAmountCents != nil && amountCents > *r.MaximumAmountCents {
+		return false
+	}
+
+	if r.Transfer != nil && *r.Transfer != (transaction.TransferAccountId != nil) {
+		return false
+	}
+
+	return true
+}
+
+// ExplainExclusions returns, for the given account IDs, each outbound transaction within the given start and end dates (inclusive)
+// that is excluded from the outbound balances calculated by CalculateOutboundTransactions, along with the reason it was excluded.
+func ExplainExclusions(
+	accountIDs []string,
+	flagColorsByAccountID map[string]*FlagColorFilter,
+	rules *TransactionRules,
+	transactions []offrampynab.ScheduledTransactionDetail,
+	startDate civil.Date,
+	endDate civil.Date,
+) ([]*TransactionExclusion, error) {
+	filteredByDate, err := filterTransactionsByDateRange(filterToAccountIDs(transactions, accountIDs), startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter transactions by date range: %w", err)
+	}
+
+	var exclusions []*TransactionExclusion
+	for _, transaction := range filterToOutboundOnly(filteredByDate) {
+		if excluded, reason := flagColorsByAccountID[transaction.AccountId].Excludes(transaction); excluded {
+			exclusions = append(exclusions, &TransactionExclusion{
+				Transaction: transaction,
+				Reason:      reason,
+			})
+
+			continue
+		}
+
+		if rule := rules.Evaluate(transaction); rule != nil && rule.Exclude {
+			exclusions = append(exclusions, &TransactionExclusion{
+				Transaction: transaction,
+				Reason:      rule.Description,
+			})
+		}
+	}
+
+	return exclusions, nil
+}
+
+func filterByRules(transactions []offrampynab.ScheduledTransactionDetail, rules *TransactionRules) []offrampynab.ScheduledTransactionDetail {
+	included := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
+
+	for _, transaction := range transactions {
+		if rule := rules.Evaluate(transaction); rule != nil && rule.Exclude {
+			continue
+		}
+
+		included = append(included, transaction)
+	}
+
+	return included
+}
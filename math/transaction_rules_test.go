@@ -0,0 +1,145 @@
+package math_test
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("TransactionRules", func() {
+	var accountID string
+	var transactions []offrampynab.ScheduledTransactionDetail
+	var dateRange civil.Date
+
+	newTransaction := func(payeeName string, amount int) offrampynab.ScheduledTransactionDetail {
+		return offrampynab.ScheduledTransactionDetail{
+			ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+				Id:        payeeName,
+				AccountId: accountID,
+				Amount:    amount,
+				DateNext:  dateRange.String(),
+			},
+			PayeeName: payeeName,
+		}
+	}
+
+	BeforeEach(func() {
+		accountID = "2e6b9c4d-8f1a-4b3c-9d5e-7f0a1b2c3d4e"
+		dateRange = civil.Date{Year: 2025, Month: time.April, Day: 10}
+		transactions = nil
+	})
+
+	Context("Evaluate", func() {
+		It("returns the first matching rule", func() {
+			payee := "amazon"
+			exclude := &math.TransactionRule{Description: "exclude everything", Exclude: true}
+			include := &math.TransactionRule{Description: "include amazon", Payee: &payee}
+			rules := &math.TransactionRules{
+				RulesByAccountID: map[string][]*math.TransactionRule{
+					accountID: {include, exclude},
+				},
+			}
+
+			Expect(rules.Evaluate(newTransaction("Amazon", -10000))).To(BeIdenticalTo(include), "the payee should be matched without regard to case")
+			Expect(rules.Evaluate(newTransaction("Netflix", -10000))).To(BeIdenticalTo(exclude), "the catch-all rule should match the remaining transaction")
+		})
+
+		It("requires all of a rule's conditions to match", func() {
+			memo := "REIMBURSED"
+			minimumAmountCents := 5000
+			rule := &math.TransactionRule{Exclude: true, MemoContains: &memo, MinimumAmountCents: &minimumAmountCents}
+			rules := &math.TransactionRules{
+				RulesByAccountID: map[string][]*math.TransactionRule{
+					accountID: {rule},
+				},
+			}
+
+			transaction := newTransaction("Hardware Store", -75000)
+			Expect(rules.Evaluate(transaction)).To(BeNil(), "a transaction without a memo should not match")
+
+			transactionMemo := "to be reimbursed by work"
+			transaction.Memo = &transactionMemo
+			Expect(rules.Evaluate(transaction)).To(BeIdenticalTo(rule), "a large transaction with the memo should match")
+
+			transaction.Amount = -1000
+			Expect(rules.Evaluate(transaction)).To(BeNil(), "a transaction below the minimum amount should not match")
+		})
+
+		It("matches on category group, payee pattern, and transfers", func() {
+			categoryID := "category-electric"
+			categoryGroup := "bills"
+			isTransfer := false
+			rule := &math.TransactionRule{
+				Exclude:       true,
+				PayeePattern:  regexp.MustCompile(`^City of `),
+				CategoryGroup: &categoryGroup,
+				Transfer:      &isTransfer,
+			}
+			rules := &math.TransactionRules{
+				RulesByAccountID: map[string][]*math.TransactionRule{
+					accountID: {rule},
+				},
+				CategoryGroupNamesByCategoryID: map[string]string{
+					categoryID: "Bills",
+				},
+			}
+
+			transaction := newTransaction("City of Springfield", -4500)
+			transaction.CategoryId = &categoryID
+			Expect(rules.Evaluate(transaction)).To(BeIdenticalTo(rule), "the transaction should match all of the conditions")
+
+			transferAccountID := "savings"
+			transaction.TransferAccountId = &transferAccountID
+			Expect(rules.Evaluate(transaction)).To(BeNil(), "a transfer should not match a rule for non-transfers")
+		})
+	})
+
+	Context("CalculateOutboundTransactions", func() {
+		It("does not count excluded transactions", func() {
+			maximumAmountCents := 1000
+			rules := &math.TransactionRules{
+				RulesByAccountID: map[string][]*math.TransactionRule{
+					accountID: {{Description: "small purchases", Exclude: true, MaximumAmountCents: &maximumAmountCents}},
+				},
+			}
+
+			transactions = append(transactions, newTransaction("Coffee", -4500), newTransaction("Rent", -1500000))
+
+			grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{accountID}, nil, rules, transactions, dateRange, dateRange)
+			Expect(err).ToNot(HaveOccurred(), "calculating the outbound transactions should not fail")
+			Expect(grouped[accountID].ToCents()).To(Equal(150000), "only the rent should be counted")
+		})
+	})
+
+	Context("ExplainExclusions", func() {
+		It("lists the flag color or rule that excluded each transaction", func() {
+			payee := "Gym"
+			rules := &math.TransactionRules{
+				RulesByAccountID: map[string][]*math.TransactionRule{
+					accountID: {{Description: "no gym", Exclude: true, Payee: &payee}},
+				},
+			}
+
+			flagColor := "green"
+			flagged := newTransaction("Streaming", -1599*10)
+			flagged.FlagColor = &flagColor
+
+			transactions = append(transactions, flagged, newTransaction("Gym", -40000), newTransaction("Rent", -1500000))
+
+			exclusions, err := math.ExplainExclusions([]string{accountID}, map[string]*math.FlagColorFilter{accountID: {Excluded: []string{flagColor}}}, rules, transactions, dateRange, dateRange)
+			Expect(err).ToNot(HaveOccurred(), "explaining the exclusions should not fail")
+			Expect(exclusions).To(HaveLen(2), "the flagged and gym transactions should be excluded")
+			Expect(exclusions[0].Transaction.PayeeName).To(Equal("Streaming"), "the flagged transaction should be excluded")
+			Expect(exclusions[0].Reason).To(Equal("excluded flag color 'green'"), "the flag color should be given as the reason")
+			Expect(exclusions[1].Transaction.PayeeName).To(Equal("Gym"), "the gym transaction should be excluded")
+			Expect(exclusions[1].Reason).To(Equal("no gym"), "the rule should be given as the reason")
+		})
+	})
+})
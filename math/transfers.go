@@ -0,0 +1,68 @@
+package math
+
+import (
+	"slices"
+
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+// MirrorInternalTransfers returns the given transactions along with, for each transfer between two of the given accounts,
+// the counterpart of the transfer in the other account.
+// YNAB lists a scheduled transfer only in the account in which it was scheduled; mirroring it allows projections of
+// the other account to reflect the money it sends or receives.
+func MirrorInternalTransfers(accountIDs []string, transactions []offrampynab.ScheduledTransactionDetail) []offrampynab.ScheduledTransactionDetail {
+	mirrored := make([]offrampynab.ScheduledTransactionDetail, 0, len(transactions))
+	mirrored = append(mirrored, transactions...)
+
+	for _, transaction := range transactions {
+		if !isInternalTransfer(accountIDs, transaction) {
+			continue
+		}
+
+		counterpart := transaction
+		counterpart.Id = transaction.Id + ":counterpart"
+		counterpart.AccountId = *transaction.TransferAccountId
+		counterpart.TransferAccountId = &transaction.AccountId
+		counterpart.Amount = -transaction.Amount
+		counterpart.Subtransactions = nil
+
+		mirrored = append(mirrored, counterpart)
+	}
+
+	return mirrored
+}
+
+// splitInternalTransfers separates the transfers between two of the given accounts from all other transactions.
+func splitInternalTransfers(accountIDs []string, transactions []offrampynab.ScheduledTransactionDetail) ([]offrampynab.ScheduledTransactionDetail, []offrampynab.ScheduledTransactionDetail) {
+	var external []offrampynab.ScheduledTransactionDetail
+	var internal []offrampynab.ScheduledTransactionDetail
+	for _, transaction := range transactions {
+		if isInternalTransfer(accountIDs, transaction) {
+			internal = append(internal, transaction)
+		} else {
+			external = append(external, transaction)
+		}
+	}
+
+	return external, internal
+}
+
+// netInternalTransfers returns, for each account, the net amount it receives (if positive) or sends (if negative)
+// through the given transfers between accounts.
+func netInternalTransfers(transfers []offrampynab.ScheduledTransactionDetail) map[string]int {
+	netByAccountID := make(map[string]int)
+	for _, transfer := range transfers {
+		netByAccountID[transfer.AccountId] += transfer.Amount
+		netByAccountID[*transfer.TransferAccountId] -= transfer.Amount
+	}
+
+	return netByAccountID
+}
+
+func isInternalTransfer(accountIDs []string, transaction offrampynab.ScheduledTransactionDetail) bool {
+	if transaction.TransferAccountId == nil || *transaction.TransferAccountId == transaction.AccountId {
+		return false
+	}
+
+	return slices.Contains(accountIDs, transaction.AccountId) && slices.Contains(accountIDs, *transaction.TransferAccountId)
+}
@@ -0,0 +1,92 @@
+package math_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/math"
+	offrampynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Transfers", func() {
+	var checkingAccountID string
+	var billsAccountID string
+	var savingsAccountID string
+	var date civil.Date
+	var transactions []offrampynab.ScheduledTransactionDetail
+
+	BeforeEach(func() {
+		checkingAccountID = "checking"
+		billsAccountID = "bills"
+		savingsAccountID = "savings"
+		date = civil.Date{Year: 2025, Month: time.May, Day: 1}
+
+		transactions = []offrampynab.ScheduledTransactionDetail{
+			{
+				// moves money between the offramp accounts
+				ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+					Id:                "checking-to-bills",
+					AccountId:         checkingAccountID,
+					TransferAccountId: &billsAccountID,
+					Amount:            -100000,
+					DateNext:          date.String(),
+				},
+			},
+			{
+				// leaves the offramp accounts
+				ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+					Id:                "checking-to-savings",
+					AccountId:         checkingAccountID,
+					TransferAccountId: &savingsAccountID,
+					Amount:            -25000,
+					DateNext:          date.String(),
+				},
+			},
+			{
+				ScheduledTransactionSummary: offrampynab.ScheduledTransactionSummary{
+					Id:        "electric",
+					AccountId: billsAccountID,
+					Amount:    -150000,
+					DateNext:  date.String(),
+				},
+			},
+		}
+	})
+
+	Context("CalculateOutboundTransactions", func() {
+		It("only funds money leaving the offramp accounts", func() {
+			grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{checkingAccountID, billsAccountID}, nil, nil, transactions, date, date)
+			Expect(err).ToNot(HaveOccurred(), "calculating the outbound transactions should not fail")
+			Expect(grouped[checkingAccountID].ToCents()).To(Equal(12500), "checking should be funded for both of its transfers out")
+			Expect(grouped[billsAccountID].ToCents()).To(Equal(5000), "bills should only be funded for what checking does not send it")
+			Expect(grouped[checkingAccountID].ToCents()+grouped[billsAccountID].ToCents()).To(Equal(17500), "the total should be the money leaving the accounts")
+		})
+
+		When("an account receives more than it spends", func() {
+			It("does not fund it", func() {
+				transactions[2].Amount = -40000
+
+				grouped, err := math.CalculateOutboundTransactions(context.Background(), []string{checkingAccountID, billsAccountID}, nil, nil, transactions, date, date)
+				Expect(err).ToNot(HaveOccurred(), "calculating the outbound transactions should not fail")
+				Expect(grouped[billsAccountID].ToCents()).To(BeZero(), "bills should not be funded")
+			})
+		})
+	})
+
+	Context("MirrorInternalTransfers", func() {
+		It("adds the counterparts of transfers between the given accounts", func() {
+			mirrored := math.MirrorInternalTransfers([]string{checkingAccountID, billsAccountID}, transactions)
+			Expect(mirrored).To(HaveLen(4), "only the transfer between the offramp accounts should be mirrored")
+
+			counterpart := mirrored[3]
+			Expect(counterpart.AccountId).To(Equal(billsAccountID), "the counterpart should be in the receiving account")
+			Expect(counterpart.Amount).To(Equal(100000), "the counterpart should be an inflow")
+			Expect(*counterpart.TransferAccountId).To(Equal(checkingAccountID), "the counterpart should refer back to the sending account")
+			Expect(counterpart.DateNext).To(Equal(date.String()), "the counterpart should be on the same date")
+		})
+	})
+})
@@ -1,17 +1,16 @@
 package math
 
-// toDollarsAndCents returns the dollars and cents of the given amount,
+import (
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+)
+
+// toDollarsAndCents returns the dollars and cents of the given YNAB transaction amount,
 // converting YNAB's three-significant-place precision to two-significant-place.
 func toDollarsAndCents(amount int) (int, int) {
-	if amount == 0 {
-		return 0, 0
-	}
-
-	// YNAB expresses cents to the third decimal place
-	cents := amount % 1000
-
-	dollars := (amount - cents) / 1000
-	cents = (cents - (cents % 10)) / 10
+	return currency.SplitCents(currency.MilliunitsToCents(amount))
+}
 
-	return dollars, cents
+// toCents converts the given YNAB transaction amount into a number of cents.
+func toCents(amount int) int {
+	return currency.MilliunitsToCents(amount)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"math"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
 )
 
 // ERC681URLGenerator is a generator that generates URLs in compliance with
@@ -24,5 +26,10 @@ func (*ERC681URLGenerator) Generate(ctx context.Context, qrDetails *Details) (st
 
 	url := fmt.Sprintf("ethereum:%s@%d/transfer?address=%s&uint256=%d", qrDetails.ContactAddress, qrDetails.ChainID, qrDetails.ReceipientAddress, tokenAmount)
 
+	logging.FromContext(ctx).DebugContext(ctx, "generated ERC-681 URL",
+		"chain_id", qrDetails.ChainID,
+		"token_amount", tokenAmount,
+		"url", url)
+
 	return url, nil
 }
@@ -1,6 +1,10 @@
 package qr
 
-import "context"
+import (
+	"context"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
+)
 
 // RecipientAddressURLGenerator is a generator that just generates
 // a URL to show the given recipient address. This is useful for wallets
@@ -14,5 +18,7 @@ func NewRecipientAddressURLGenerator() *RecipientAddressURLGenerator {
 }
 
 func (*RecipientAddressURLGenerator) Generate(ctx context.Context, qrDetails *Details) (string, error) {
+	logging.FromContext(ctx).DebugContext(ctx, "generated recipient-only URL", "recipient_address", qrDetails.ReceipientAddress)
+
 	return qrDetails.ReceipientAddress, nil
 }
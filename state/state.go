@@ -0,0 +1,154 @@
+// Package state records which windows of dates have been funded, so that windows missed between runs can be caught up on.
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// Window is a range of dates (inclusive) whose transactions are funded together.
+type Window struct {
+	StartDate civil.Date `json:"start_date"`
+	EndDate   civil.Date `json:"end_date"`
+}
+
+// FundedWindow is a window whose funding was recorded in YNAB.
+type FundedWindow struct {
+	Window
+	FundedAt time.Time `json:"funded_at"` // when the transactions funding the window were posted
+}
+
+// State is the record of the windows that have been funded.
+type State struct {
+	FundedWindows []FundedWindow `json:"funded_windows"`
+}
+
+// Load reads the state from the given file. If the file does not exist, then an empty state is returned.
+func Load(path string) (*State, error) {
+	fileBytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &State{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read state file '%s': %w", path, err)
+	}
+
+	var state State
+	if err := json.Unmarshal(fileBytes, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file '%s': %w", path, err)
+	}
+
+	return &state, nil
+}
+
+// Save writes the state to the given file. The state is written to a temporary file that then replaces the given file,
+// so that an interrupted write does not lose the windows previously recorded.
+func (s *State) Save(path string) error {
+	stateBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary state file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+
+	if _, err := tempFile.Write(stateBytes); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("failed to write temporary state file '%s': %w", tempFile.Name(), err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary state file '%s': %w", tempFile.Name(), err)
+	}
+
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace state file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+// LastFundedEndDate returns the latest end date of all of the funded windows and true, or false if no window has been funded.
+func (s *State) LastFundedEndDate() (civil.Date, bool) {
+	var lastEndDate civil.Date
+	for _, fundedWindow := range s.FundedWindows {
+		if lastEndDate.IsZero() || fundedWindow.EndDate.After(lastEndDate) {
+			lastEndDate = fundedWindow.EndDate
+		}
+	}
+
+	return lastEndDate, !lastEndDate.IsZero()
+}
+
+// RecordFunded records the given windows as having been funded at the given time.
+func (s *State) RecordFunded(fundedAt time.Time, windows ...Window) {
+	for _, window := range windows {
+		s.FundedWindows = append(s.FundedWindows, FundedWindow{
+			Window:   window,
+			FundedAt: fundedAt,
+		})
+	}
+}
+
+// RemoveFunded removes the record of the funded windows that lie entirely within the given dates (inclusive),
+// such as when the transactions that funded them have been reverted. It returns the number of windows removed.
+func (s *State) RemoveFunded(startDate, endDate civil.Date) int {
+	remaining := make([]FundedWindow, 0, len(s.FundedWindows))
+	for _, fundedWindow := range s.FundedWindows {
+		if !fundedWindow.StartDate.Before(startDate) && !fundedWindow.EndDate.After(endDate) {
+			continue
+		}
+
+		remaining = append(remaining, fundedWindow)
+	}
+
+	removedCount := len(s.FundedWindows) - len(remaining)
+	s.FundedWindows = remaining
+
+	return removedCount
+}
+
+// UnfundedWindows returns the windows between the last funded end date and the given start date of the window to be funded,
+// split according to the given cadence. If no window has been funded, or the last funded window ends on or after
+// the day before the given start date, then no windows are returned.
+func (s *State) UnfundedWindows(cadence calendar.Cadence, targetStartDate civil.Date) []Window {
+	lastEndDate, hasFunded := s.LastFundedEndDate()
+	if !hasFunded {
+		return nil
+	}
+
+	var windows []Window
+	for startDate := lastEndDate.AddDays(1); startDate.Before(targetStartDate); {
+		// the first window of the cadence that starts on or after the start date
+		cadenceStartDate, cadenceEndDate := cadence.NextWindow(startDate.AddDays(-1))
+
+		endDate := cadenceEndDate
+		if cadenceStartDate != startDate {
+			// the funded windows were not aligned to the cadence, so fill the gap up to the next window of the cadence
+			endDate = cadenceStartDate.AddDays(-1)
+		}
+
+		if !endDate.Before(targetStartDate) {
+			endDate = targetStartDate.AddDays(-1)
+		}
+
+		windows = append(windows, Window{
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+
+		startDate = endDate.AddDays(1)
+	}
+
+	return windows
+}
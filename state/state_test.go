@@ -0,0 +1,162 @@
+package state_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/calendar"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/state"
+)
+
+var _ = Describe("State", func() {
+	parseWindow := func(startDate, endDate string) state.Window {
+		parsedStartDate, err := civil.ParseDate(startDate)
+		Expect(err).ToNot(HaveOccurred(), "the start date should parse")
+
+		parsedEndDate, err := civil.ParseDate(endDate)
+		Expect(err).ToNot(HaveOccurred(), "the end date should parse")
+
+		return state.Window{
+			StartDate: parsedStartDate,
+			EndDate:   parsedEndDate,
+		}
+	}
+
+	fundedAt := time.Date(2025, time.July, 1, 8, 0, 0, 0, time.UTC)
+
+	Context("Load", func() {
+		It("returns an empty state if the file does not exist", func() {
+			fundingState, err := state.Load(filepath.Join(GinkgoT().TempDir(), "missing.json"))
+			Expect(err).ToNot(HaveOccurred(), "loading a missing state file should not fail")
+			Expect(fundingState.FundedWindows).To(BeEmpty(), "no windows should have been funded")
+
+			_, hasFunded := fundingState.LastFundedEndDate()
+			Expect(hasFunded).To(BeFalse(), "there should be no last funded end date")
+		})
+
+		It("reads the state written by Save", func() {
+			statePath := filepath.Join(GinkgoT().TempDir(), "state.json")
+
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-13"))
+			Expect(fundingState.Save(statePath)).To(Succeed(), "saving the state should succeed")
+
+			loadedState, err := state.Load(statePath)
+			Expect(err).ToNot(HaveOccurred(), "loading the state should not fail")
+			Expect(loadedState.FundedWindows).To(HaveLen(1), "the funded window should be read")
+			Expect(loadedState.FundedWindows[0].Window).To(Equal(parseWindow("2025-07-07", "2025-07-13")), "the funded window's dates should be read")
+			Expect(loadedState.FundedWindows[0].FundedAt.Equal(fundedAt)).To(BeTrue(), "the time at which the window was funded should be read")
+		})
+
+		It("rejects a file that is not valid JSON", func() {
+			statePath := filepath.Join(GinkgoT().TempDir(), "state.json")
+			Expect(os.WriteFile(statePath, []byte("funded_windows:"), 0o600)).To(Succeed(), "writing the state file should succeed")
+
+			_, err := state.Load(statePath)
+			Expect(err).To(HaveOccurred(), "loading an invalid state file should fail")
+		})
+	})
+
+	Context("LastFundedEndDate", func() {
+		It("returns the latest end date regardless of the order in which windows were funded", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-14", "2025-07-20"), parseWindow("2025-07-07", "2025-07-13"))
+
+			lastEndDate, hasFunded := fundingState.LastFundedEndDate()
+			Expect(hasFunded).To(BeTrue(), "there should be a last funded end date")
+			Expect(lastEndDate.String()).To(Equal("2025-07-20"), "the latest end date should be returned")
+		})
+	})
+
+	Context("RemoveFunded", func() {
+		It("removes the windows that lie entirely within the given dates", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt,
+				parseWindow("2025-07-07", "2025-07-13"),
+				parseWindow("2025-07-14", "2025-07-20"),
+				parseWindow("2025-07-21", "2025-07-27"),
+			)
+
+			removedWindow := parseWindow("2025-07-10", "2025-07-27")
+			Expect(fundingState.RemoveFunded(removedWindow.StartDate, removedWindow.EndDate)).To(Equal(2), "the two windows within the dates should be removed")
+			Expect(fundingState.FundedWindows).To(HaveLen(1), "only the window that starts before the dates should remain")
+			Expect(fundingState.FundedWindows[0].Window).To(Equal(parseWindow("2025-07-07", "2025-07-13")), "the window that starts before the dates should remain")
+		})
+	})
+
+	Context("UnfundedWindows", func() {
+		weekly := &calendar.WeeklyCadence{WeekStart: time.Monday, Weeks: 1}
+
+		It("returns the windows missed between the last funded window and the target window", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-13"))
+
+			targetStartDate, _ := civil.ParseDate("2025-07-28")
+			Expect(fundingState.UnfundedWindows(weekly, targetStartDate)).To(Equal([]state.Window{
+				parseWindow("2025-07-14", "2025-07-20"),
+				parseWindow("2025-07-21", "2025-07-27"),
+			}), "each missed week should be returned")
+		})
+
+		It("fills the gap before the cadence's next window if the last funded window was not aligned to it", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-10"))
+
+			targetStartDate, _ := civil.ParseDate("2025-07-21")
+			Expect(fundingState.UnfundedWindows(weekly, targetStartDate)).To(Equal([]state.Window{
+				parseWindow("2025-07-11", "2025-07-13"),
+				parseWindow("2025-07-14", "2025-07-20"),
+			}), "the gap and then the missed week should be returned")
+		})
+
+		It("ends the last missed window the day before the target window", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-13"))
+
+			targetStartDate, _ := civil.ParseDate("2025-07-17")
+			Expect(fundingState.UnfundedWindows(weekly, targetStartDate)).To(Equal([]state.Window{
+				parseWindow("2025-07-14", "2025-07-16"),
+			}), "the missed window should not overlap the target window")
+		})
+
+		It("does not return a window that was recorded by a run for which nothing needed funding", func() {
+			stateFile := filepath.Join(GinkgoT().TempDir(), "state.json")
+
+			// the first run funds its window
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-13"))
+			Expect(fundingState.Save(stateFile)).To(Succeed(), "saving the first run's state should succeed")
+
+			// the second run has no bills to fund but still records its window as handled
+			fundingState, err := state.Load(stateFile)
+			Expect(err).ToNot(HaveOccurred(), "loading the first run's state should not fail")
+			fundingState.RecordFunded(fundedAt.AddDate(0, 0, 7), parseWindow("2025-07-14", "2025-07-20"))
+			Expect(fundingState.Save(stateFile)).To(Succeed(), "saving the second run's state should succeed")
+
+			// the third run catches up
+			fundingState, err = state.Load(stateFile)
+			Expect(err).ToNot(HaveOccurred(), "loading the second run's state should not fail")
+
+			targetStartDate, _ := civil.ParseDate("2025-07-21")
+			Expect(fundingState.UnfundedWindows(weekly, targetStartDate)).To(BeEmpty(), "the window that needed no funding should not be caught up on")
+		})
+
+		It("returns nothing if the target window follows the last funded window", func() {
+			fundingState := &state.State{}
+			fundingState.RecordFunded(fundedAt, parseWindow("2025-07-07", "2025-07-13"))
+
+			targetStartDate, _ := civil.ParseDate("2025-07-14")
+			Expect(fundingState.UnfundedWindows(weekly, targetStartDate)).To(BeEmpty(), "no windows should have been missed")
+		})
+
+		It("returns nothing if no window has been funded", func() {
+			targetStartDate, _ := civil.ParseDate("2025-07-14")
+			Expect((&state.State{}).UnfundedWindows(weekly, targetStartDate)).To(BeEmpty(), "there is no funded window from which to catch up")
+		})
+	})
+})
@@ -0,0 +1,34 @@
+package ynab
+
+import (
+	"context"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+)
+
+// API is the part of YNAB's v1 API used by this tool. Client implements it over HTTP;
+// the rest of the tool depends only on this interface and the types of this package.
+type API interface {
+	// ListBudgets lists the budgets to which the access token has access.
+	ListBudgets(ctx context.Context) ([]BudgetSummary, error)
+	// ListCategories lists the budget's category groups and their categories.
+	ListCategories(ctx context.Context, budgetID string) ([]CategoryGroup, error)
+	// ListAccountsSince lists the budget's accounts that have changed since the given server knowledge.
+	ListAccountsSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[Account], int, error)
+	// ListPayeesSince lists the budget's payees that have changed since the given server knowledge.
+	ListPayeesSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[Payee], int, error)
+	// ListScheduledTransactionsSince lists the budget's scheduled transactions that have changed since the given server knowledge.
+	ListScheduledTransactionsSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[ScheduledTransactionDetail], int, error)
+	// ListAccountTransactionsSince lists the transactions in the given account that are dated on or after the given date.
+	ListAccountTransactionsSince(ctx context.Context, budgetID string, accountID string, sinceDate civil.Date) ([]TransactionDetail, error)
+	// GetTransaction returns the transaction with the given ID, or nil if it does not exist or has been deleted.
+	GetTransaction(ctx context.Context, budgetID string, transactionID string) (*TransactionDetail, error)
+	// SaveTransactions creates the given transactions and returns the transactions that were created.
+	SaveTransactions(ctx context.Context, budgetID string, transactions []SaveTransaction) (*SavedTransactions, error)
+	// DeleteTransaction deletes the transaction with the given ID.
+	DeleteTransaction(ctx context.Context, budgetID string, transactionID string) error
+	// CreateScheduledTransaction creates the given scheduled transaction.
+	CreateScheduledTransaction(ctx context.Context, budgetID string, scheduledTransaction SaveScheduledTransaction) (*ScheduledTransactionDetail, error)
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,20 @@
+package ynab
+
+import "fmt"
+
+// BalanceSweep represents the excess balance to be moved out of an account
+// so that it does not exceed its maximum balance after a set of scheduled transactions have been applied.
+type BalanceSweep struct {
+	Dollars              int
+	Cents                int
+	DestinationAccountID string // the ID of the account to which the excess is to be moved
+}
+
+// ToCents expresses the amount in just cents.
+func (b *BalanceSweep) ToCents() int {
+	return (b.Dollars * 100) + b.Cents
+}
+
+func (b *BalanceSweep) String() string {
+	return fmt.Sprintf("$%d.%02d", b.Dollars, b.Cents)
+}
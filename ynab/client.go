@@ -0,0 +1,179 @@
+package ynab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/ynab/ynabapi"
+)
+
+// Client makes requests to YNAB's v1 API through the client generated from its OpenAPI spec.
+type Client struct {
+	api *ynabapi.Client
+}
+
+// NewClient creates a new Client that sends requests relative to the given base URL
+// using the given HTTP client and access token.
+func NewClient(baseURL *url.URL, httpClient *http.Client, accessToken string) *Client {
+	server := baseURL.String()
+	if !strings.HasSuffix(server, "/") {
+		server += "/"
+	}
+
+	return &Client{
+		api: &ynabapi.Client{
+			Server: server,
+			Client: httpClient,
+			RequestEditors: []ynabapi.RequestEditorFn{
+				func(_ context.Context, request *http.Request) error {
+					request.Header.Set("Authorization", "Bearer "+accessToken)
+					request.Header.Set("Accept", "application/json")
+
+					return nil
+				},
+			},
+		},
+	}
+}
+
+// ListBudgets lists the budgets to which the access token has access.
+func (c *Client) ListBudgets(ctx context.Context) ([]BudgetSummary, error) {
+	response, err := parseResponse[ynabapi.BudgetSummaryResponse](c.api.GetBudgets(ctx, &ynabapi.GetBudgetsParams{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	return response.Data.Budgets, nil
+}
+
+// ListCategories lists the budget's category groups and their categories, with the categories' amounts for the current month.
+func (c *Client) ListCategories(ctx context.Context, budgetID string) ([]CategoryGroup, error) {
+	response, err := parseResponse[ynabapi.CategoriesResponse](c.api.GetCategories(ctx, budgetID, &ynabapi.GetCategoriesParams{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	return response.Data.CategoryGroups, nil
+}
+
+// ListAccountTransactionsSince lists the transactions in the given account that are dated on or after the given date.
+// Scheduled transactions are not included.
+func (c *Client) ListAccountTransactionsSince(ctx context.Context, budgetID string, accountID string, sinceDate civil.Date) ([]TransactionDetail, error) {
+	sinceDateString := sinceDate.String()
+	response, err := parseResponse[ynabapi.TransactionsResponse](c.api.GetTransactionsByAccount(ctx, budgetID, accountID, &ynabapi.GetTransactionsByAccountParams{
+		SinceDate: &sinceDateString,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions for account ID '%s' since %s: %w", accountID, sinceDateString, err)
+	}
+
+	return response.Data.Transactions, nil
+}
+
+// SavedTransactions describes the transactions created by SaveTransactions.
+type SavedTransactions struct {
+	Transactions       []TransactionDetail // the transactions that were created
+	DuplicateImportIDs []string            // the import IDs of the transactions that were not created because a transaction with the same import ID already exists
+}
+
+// SaveTransactions creates the given transactions and returns the transactions that were created, including their IDs.
+func (c *Client) SaveTransactions(ctx context.Context, budgetID string, transactions []SaveTransaction) (*SavedTransactions, error) {
+	response, err := parseResponse[ynabapi.SaveTransactionsResponse](c.api.CreateTransaction(ctx, budgetID, ynabapi.PostTransactionsWrapper{
+		Transactions: &transactions,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %d transactions: %w", len(transactions), err)
+	}
+
+	saved := &SavedTransactions{}
+	if response.Data.Transactions != nil {
+		saved.Transactions = *response.Data.Transactions
+	}
+	if response.Data.DuplicateImportIds != nil {
+		saved.DuplicateImportIDs = *response.Data.DuplicateImportIds
+	}
+
+	return saved, nil
+}
+
+// GetTransaction returns the transaction with the given ID, or nil if it does not exist or has been deleted.
+func (c *Client) GetTransaction(ctx context.Context, budgetID string, transactionID string) (*TransactionDetail, error) {
+	response, err := parseResponse[ynabapi.TransactionResponse](c.api.GetTransactionById(ctx, budgetID, transactionID))
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to get transaction ID '%s': %w", transactionID, err)
+	}
+
+	transaction := response.Data.Transaction
+	if transaction.Deleted {
+		return nil, nil
+	}
+
+	return &transaction, nil
+}
+
+// DeleteTransaction deletes the transaction with the given ID.
+func (c *Client) DeleteTransaction(ctx context.Context, budgetID string, transactionID string) error {
+	if _, err := parseResponse[ynabapi.TransactionResponse](c.api.DeleteTransaction(ctx, budgetID, transactionID)); err != nil {
+		return fmt.Errorf("failed to delete transaction ID '%s': %w", transactionID, err)
+	}
+
+	return nil
+}
+
+// CreateScheduledTransaction creates the given scheduled transaction and returns it as it was created.
+func (c *Client) CreateScheduledTransaction(ctx context.Context, budgetID string, scheduledTransaction SaveScheduledTransaction) (*ScheduledTransactionDetail, error) {
+	response, err := parseResponse[ynabapi.ScheduledTransactionResponse](c.api.CreateScheduledTransaction(ctx, budgetID, ynabapi.PostScheduledTransactionWrapper{
+		ScheduledTransaction: scheduledTransaction,
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scheduled transaction: %w", err)
+	}
+
+	return &response.Data.ScheduledTransaction, nil
+}
+
+// isNotFound returns true if the given error is the API's response that the requested resource does not exist.
+func isNotFound(err error) bool {
+	var errorResponse *ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == http.StatusNotFound
+}
+
+// parseResponse decodes the body of the response to a request made by the generated client into the given generated
+// response type, returning an *ErrorResponse if the API reported that the request failed.
+func parseResponse[T any](httpResponse *http.Response, requestErr error) (*T, error) {
+	if requestErr != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", requestErr)
+	}
+	defer httpResponse.Body.Close()
+
+	responseBytes, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		errorResponse := &ErrorResponse{Response: httpResponse}
+		// Not all error responses will have a body that can be parsed, so ignore any failures to parse it
+		_ = json.Unmarshal(responseBytes, errorResponse)
+
+		return nil, errorResponse
+	}
+
+	var response T
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,284 @@
+package ynab_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("Client", func() {
+	var server *httptest.Server
+	var requests []*http.Request
+	var requestBodies []string
+	var responseStatus int
+	var responseBody string
+	var client *cliynab.Client
+
+	BeforeEach(func() {
+		requests = nil
+		requestBodies = nil
+		responseStatus = http.StatusOK
+		responseBody = ""
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r)
+			requestBody, _ := io.ReadAll(r.Body)
+			requestBodies = append(requestBodies, string(requestBody))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(responseStatus)
+			_, _ = w.Write([]byte(responseBody))
+		}))
+		DeferCleanup(server.Close)
+
+		baseURL, err := url.Parse(server.URL + "/v1/")
+		Expect(err).ToNot(HaveOccurred(), "parsing the test server URL should not fail")
+
+		client = cliynab.NewClient(baseURL, server.Client(), "access-token")
+	})
+
+	Context("ListAccountTransactionsSince", func() {
+		It("requests the account's transactions since the given date", func() {
+			responseBody = `{"data":{"transactions":[{"id":"transaction-0","date":"2025-03-02","amount":-12340,"cleared":"uncleared","account_id":"account-0","payee_name":"Electric Co"}]}}`
+
+			transactions, err := client.ListAccountTransactionsSince(context.Background(), "budget-0", "account-0", civil.Date{Year: 2025, Month: time.March, Day: 1})
+			Expect(err).ToNot(HaveOccurred(), "listing transactions should not fail")
+			Expect(transactions).To(HaveLen(1), "the listed transaction should be returned")
+			Expect(transactions[0].Id).To(Equal("transaction-0"), "the transaction ID should be parsed")
+			Expect(transactions[0].Amount).To(Equal(-12340), "the transaction amount should be parsed")
+			Expect(transactions[0].Cleared).To(Equal("uncleared"), "the cleared status should be parsed")
+			Expect(transactions[0].PayeeName).To(Equal("Electric Co"), "the payee name should be parsed")
+
+			Expect(requests).To(HaveLen(1), "a single request should be made")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/accounts/account-0/transactions"), "the account's transactions should be requested")
+			Expect(requests[0].URL.Query().Get("since_date")).To(Equal("2025-03-01"), "the since date should be sent")
+			Expect(requests[0].Header.Get("Authorization")).To(Equal("Bearer access-token"), "the access token should be sent")
+		})
+
+		When("the API responds with an error", func() {
+			It("returns the error", func() {
+				responseStatus = http.StatusNotFound
+				responseBody = `{"error":{"id":"404.2","name":"resource_not_found","detail":"Resource not found"}}`
+
+				_, err := client.ListAccountTransactionsSince(context.Background(), "budget-0", "account-0", civil.DateOf(time.Now()))
+				Expect(err).To(HaveOccurred(), "an error response should fail the request")
+				Expect(err.Error()).To(ContainSubstring("Resource not found"), "the error detail should be reported")
+			})
+		})
+	})
+
+	Context("SaveTransactions", func() {
+		It("posts the transactions and returns the created transactions", func() {
+			responseStatus = http.StatusCreated
+			responseBody = `{"data":{"transaction_ids":["transaction-0"],"transactions":[{"id":"transaction-0","date":"2025-03-02","amount":-12340,"account_id":"account-0","import_id":"offramp:run-0:0"}],"duplicate_import_ids":["offramp:run-0:1"]}}`
+
+			saved, err := client.SaveTransactions(context.Background(), "budget-0", []cliynab.SaveTransaction{
+				{
+					SaveTransactionWithOptionalFields: cliynab.SaveTransactionFields{
+						AccountId: "account-0",
+						Date:      "2025-03-02",
+						Amount:    -12340,
+					},
+					ImportId: cliynab.ImportID("run-0", 0),
+				},
+				{
+					SaveTransactionWithOptionalFields: cliynab.SaveTransactionFields{
+						AccountId: "account-0",
+						Date:      "2025-03-02",
+						Amount:    -100,
+					},
+					ImportId: cliynab.ImportID("run-0", 1),
+				},
+			})
+			Expect(err).ToNot(HaveOccurred(), "saving the transactions should not fail")
+			Expect(saved.Transactions).To(HaveLen(1), "the created transaction should be returned")
+			Expect(saved.Transactions[0].Id).To(Equal("transaction-0"), "the ID of the created transaction should be parsed")
+			Expect(saved.DuplicateImportIDs).To(Equal([]string{"offramp:run-0:1"}), "the duplicate import IDs should be parsed")
+
+			Expect(requests).To(HaveLen(1), "a single request should be made")
+			Expect(requests[0].Method).To(Equal(http.MethodPost), "the transactions should be posted")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/transactions"), "the budget's transactions should be created")
+			Expect(requests[0].Header.Get("Content-Type")).To(Equal("application/json"), "the body should be sent as JSON")
+			Expect(requestBodies[0]).To(ContainSubstring(`"import_id":"offramp:run-0:0"`), "the import IDs should be sent")
+			Expect(requestBodies[0]).To(HavePrefix(`{"transactions":[`), "the transactions should be wrapped")
+		})
+	})
+
+	Context("GetTransaction", func() {
+		It("returns the transaction", func() {
+			responseBody = `{"data":{"transaction":{"id":"transaction-0","date":"2025-03-02","amount":-12340,"account_id":"account-0","import_id":"offramp:run-0:0","deleted":false}}}`
+
+			transaction, err := client.GetTransaction(context.Background(), "budget-0", "transaction-0")
+			Expect(err).ToNot(HaveOccurred(), "getting the transaction should not fail")
+			Expect(transaction).ToNot(BeNil(), "the transaction should be returned")
+			Expect(transaction.Amount).To(Equal(-12340), "the transaction amount should be parsed")
+			Expect(*transaction.ImportId).To(Equal("offramp:run-0:0"), "the import ID should be parsed")
+
+			Expect(requests).To(HaveLen(1), "a single request should be made")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/transactions/transaction-0"), "the transaction should be requested")
+		})
+
+		It("returns nothing if the transaction has been deleted", func() {
+			responseBody = `{"data":{"transaction":{"id":"transaction-0","deleted":true}}}`
+
+			transaction, err := client.GetTransaction(context.Background(), "budget-0", "transaction-0")
+			Expect(err).ToNot(HaveOccurred(), "getting a deleted transaction should not fail")
+			Expect(transaction).To(BeNil(), "a deleted transaction should not be returned")
+		})
+
+		It("returns nothing if the transaction does not exist", func() {
+			responseStatus = http.StatusNotFound
+			responseBody = `{"error":{"id":"404.2","name":"resource_not_found","detail":"Resource not found"}}`
+
+			transaction, err := client.GetTransaction(context.Background(), "budget-0", "transaction-0")
+			Expect(err).ToNot(HaveOccurred(), "getting a missing transaction should not fail")
+			Expect(transaction).To(BeNil(), "a missing transaction should not be returned")
+		})
+	})
+
+	Context("DeleteTransaction", func() {
+		It("deletes the transaction", func() {
+			responseBody = `{"data":{"transaction":{"id":"transaction-0","deleted":true}}}`
+
+			Expect(client.DeleteTransaction(context.Background(), "budget-0", "transaction-0")).To(Succeed(), "deleting the transaction should succeed")
+
+			Expect(requests).To(HaveLen(1), "a single request should be made")
+			Expect(requests[0].Method).To(Equal(http.MethodDelete), "the transaction should be deleted")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/transactions/transaction-0"), "the transaction should be identified by its ID")
+		})
+	})
+
+	Context("ListAccountsSince", func() {
+		It("requests all of the accounts when there is no server knowledge", func() {
+			responseBody = `{"data":{"accounts":[{"id":"account-0","name":"Checking","balance":123450,"deleted":false}],"server_knowledge":42}}`
+
+			changes, serverKnowledge, err := client.ListAccountsSince(context.Background(), "budget-0", 0)
+			Expect(err).ToNot(HaveOccurred(), "listing the accounts should not fail")
+			Expect(serverKnowledge).To(Equal(42), "the server knowledge should be parsed")
+			Expect(changes).To(HaveLen(1), "the listed account should be returned")
+			Expect(changes[0].Entity.Id).To(Equal("account-0"), "the account ID should be parsed")
+			Expect(changes[0].Entity.Balance).To(Equal(123450), "the account balance should be parsed")
+			Expect(changes[0].Deleted).To(BeFalse(), "the account should not be deleted")
+
+			Expect(requests).To(HaveLen(1), "a single request should be made")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/accounts"), "the budget's accounts should be requested")
+			Expect(requests[0].URL.Query().Has("last_knowledge_of_server")).To(BeFalse(), "no server knowledge should be sent")
+		})
+
+		It("requests only the changes since the given server knowledge", func() {
+			responseBody = `{"data":{"accounts":[{"id":"account-0","deleted":true}],"server_knowledge":43}}`
+
+			changes, serverKnowledge, err := client.ListAccountsSince(context.Background(), "budget-0", 42)
+			Expect(err).ToNot(HaveOccurred(), "listing the changed accounts should not fail")
+			Expect(serverKnowledge).To(Equal(43), "the new server knowledge should be parsed")
+			Expect(changes).To(HaveLen(1), "the changed account should be returned")
+			Expect(changes[0].Deleted).To(BeTrue(), "the deletion of the account should be parsed")
+
+			Expect(requests[0].URL.Query().Get("last_knowledge_of_server")).To(Equal("42"), "the server knowledge should be sent")
+		})
+	})
+
+	Context("ListPayeesSince", func() {
+		It("requests the changed payees", func() {
+			responseBody = `{"data":{"payees":[{"id":"payee-0","name":"Transfer : Checking","transfer_account_id":"account-0"}],"server_knowledge":7}}`
+
+			changes, serverKnowledge, err := client.ListPayeesSince(context.Background(), "budget-0", 6)
+			Expect(err).ToNot(HaveOccurred(), "listing the payees should not fail")
+			Expect(serverKnowledge).To(Equal(7), "the server knowledge should be parsed")
+			Expect(changes).To(HaveLen(1), "the listed payee should be returned")
+			Expect(*changes[0].Entity.TransferAccountId).To(Equal("account-0"), "the transfer account ID should be parsed")
+
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/payees"), "the budget's payees should be requested")
+			Expect(requests[0].URL.Query().Get("last_knowledge_of_server")).To(Equal("6"), "the server knowledge should be sent")
+		})
+	})
+
+	Context("ListScheduledTransactionsSince", func() {
+		It("requests the changed scheduled transactions", func() {
+			responseBody = `{"data":{"scheduled_transactions":[{"id":"scheduled-0","date_next":"2025-03-05","frequency":"monthly","amount":-12340,"account_id":"account-0","subtransactions":[]}],"server_knowledge":9}}`
+
+			changes, serverKnowledge, err := client.ListScheduledTransactionsSince(context.Background(), "budget-0", 0)
+			Expect(err).ToNot(HaveOccurred(), "listing the scheduled transactions should not fail")
+			Expect(serverKnowledge).To(Equal(9), "the server knowledge should be parsed")
+			Expect(changes).To(HaveLen(1), "the listed scheduled transaction should be returned")
+			Expect(changes[0].Entity.DateNext).To(Equal("2025-03-05"), "the next date should be parsed")
+			Expect(changes[0].Entity.Amount).To(Equal(-12340), "the amount should be parsed")
+
+			Expect(changes[0].Entity.Subtransactions).To(BeEmpty(), "the scheduled transaction should not be split")
+
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/scheduled_transactions"), "the budget's scheduled transactions should be requested")
+		})
+
+		It("parses the transfer accounts of scheduled subtransactions", func() {
+			responseBody = `{"data":{"scheduled_transactions":[{"id":"scheduled-0","date_next":"2025-03-05","amount":-20000,"account_id":"account-0","subtransactions":[{"id":"sub-0","amount":-10000,"transfer_account_id":"account-1"},{"id":"sub-1","amount":-10000,"deleted":true}]}],"server_knowledge":10}}`
+
+			changes, _, err := client.ListScheduledTransactionsSince(context.Background(), "budget-0", 9)
+			Expect(err).ToNot(HaveOccurred(), "listing the scheduled transactions should not fail")
+			Expect(changes[0].Entity.Subtransactions).To(HaveLen(2), "the subtransactions should be parsed")
+			Expect(*changes[0].Entity.Subtransactions[0].TransferAccountId).To(Equal("account-1"), "the transfer account of the subtransaction should be parsed")
+			Expect(changes[0].Entity.Subtransactions[1].Deleted).To(BeTrue(), "the deletion of the subtransaction should be parsed")
+		})
+	})
+
+	Context("ListBudgets", func() {
+		It("lists the budgets with their currency formats", func() {
+			responseBody = `{"data":{"budgets":[{"id":"budget-0","name":"Household","currency_format":{"iso_code":"USD","decimal_digits":2,"currency_symbol":"$"}}]}}`
+
+			budgets, err := client.ListBudgets(context.Background())
+			Expect(err).ToNot(HaveOccurred(), "listing the budgets should not fail")
+			Expect(budgets).To(HaveLen(1), "the listed budget should be returned")
+			Expect(budgets[0].Name).To(Equal("Household"), "the budget name should be parsed")
+			Expect(budgets[0].CurrencyFormat).ToNot(BeNil(), "the currency format should be parsed")
+			Expect(budgets[0].CurrencyFormat.IsoCode).To(Equal("USD"), "the currency's ISO code should be parsed")
+			Expect(budgets[0].CurrencyFormat.DecimalDigits).To(Equal(2), "the currency's decimal digits should be parsed")
+
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets"), "the budgets should be requested")
+		})
+	})
+
+	Context("ListCategories", func() {
+		It("lists the category groups and their categories", func() {
+			responseBody = `{"data":{"category_groups":[{"id":"group-0","name":"Credit Card Payments","categories":[{"id":"category-0","category_group_id":"group-0","name":"Visa","balance":50000}]}]}}`
+
+			categoryGroups, err := client.ListCategories(context.Background(), "budget-0")
+			Expect(err).ToNot(HaveOccurred(), "listing the categories should not fail")
+			Expect(categoryGroups).To(HaveLen(1), "the listed category group should be returned")
+			Expect(categoryGroups[0].Name).To(Equal("Credit Card Payments"), "the category group name should be parsed")
+			Expect(categoryGroups[0].Categories).To(HaveLen(1), "the group's category should be parsed")
+			Expect(categoryGroups[0].Categories[0].Balance).To(Equal(50000), "the category balance should be parsed")
+
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/categories"), "the budget's categories should be requested")
+		})
+	})
+
+	Context("CreateScheduledTransaction", func() {
+		It("posts the scheduled transaction and returns it as created", func() {
+			responseStatus = http.StatusCreated
+			responseBody = `{"data":{"scheduled_transaction":{"id":"scheduled-0","date_next":"2025-04-01","frequency":"monthly","amount":-50000,"account_id":"account-0","flag_name":"Rent","subtransactions":[]}}}`
+
+			frequency := "monthly"
+			scheduledTransaction, err := client.CreateScheduledTransaction(context.Background(), "budget-0", cliynab.SaveScheduledTransaction{
+				AccountId: "account-0",
+				Date:      "2025-04-01",
+				Amount:    -50000,
+				Frequency: &frequency,
+			})
+			Expect(err).ToNot(HaveOccurred(), "creating the scheduled transaction should not fail")
+			Expect(scheduledTransaction.Id).To(Equal("scheduled-0"), "the ID of the created scheduled transaction should be parsed")
+			Expect(*scheduledTransaction.FlagName).To(Equal("Rent"), "the flag name should be parsed")
+
+			Expect(requests[0].Method).To(Equal(http.MethodPost), "the scheduled transaction should be posted")
+			Expect(requests[0].URL.Path).To(Equal("/v1/budgets/budget-0/scheduled_transactions"), "the budget's scheduled transactions should be created")
+			Expect(requestBodies[0]).To(HavePrefix(`{"scheduled_transaction":{`), "the scheduled transaction should be wrapped")
+			Expect(requestBodies[0]).To(ContainSubstring(`"frequency":"monthly"`), "the frequency should be sent")
+		})
+	})
+})
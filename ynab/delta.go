@@ -0,0 +1,78 @@
+package ynab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/ynab/ynabapi"
+)
+
+// Changed is an entity returned by a delta request: its current state, and whether it has since been deleted.
+type Changed[T any] struct {
+	Entity  T
+	Deleted bool
+}
+
+// ListAccountsSince lists the budget's accounts that have changed since the given server knowledge, returning them
+// with the server knowledge as of this request. A server knowledge of 0 lists all of the accounts.
+func (c *Client) ListAccountsSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[Account], int, error) {
+	response, err := parseResponse[ynabapi.AccountsResponse](c.api.GetAccounts(ctx, budgetID, &ynabapi.GetAccountsParams{
+		LastKnowledgeOfServer: knowledgeParam(lastKnowledgeOfServer),
+	}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list accounts since server knowledge %d: %w", lastKnowledgeOfServer, err)
+	}
+
+	return toChanges(response.Data.Accounts, func(account Account) bool { return account.Deleted }), response.Data.ServerKnowledge, nil
+}
+
+// ListPayeesSince lists the budget's payees that have changed since the given server knowledge, returning them
+// with the server knowledge as of this request. A server knowledge of 0 lists all of the payees.
+func (c *Client) ListPayeesSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[Payee], int, error) {
+	response, err := parseResponse[ynabapi.PayeesResponse](c.api.GetPayees(ctx, budgetID, &ynabapi.GetPayeesParams{
+		LastKnowledgeOfServer: knowledgeParam(lastKnowledgeOfServer),
+	}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list payees since server knowledge %d: %w", lastKnowledgeOfServer, err)
+	}
+
+	return toChanges(response.Data.Payees, func(payee Payee) bool { return payee.Deleted }), response.Data.ServerKnowledge, nil
+}
+
+// ListScheduledTransactionsSince lists the budget's scheduled transactions that have changed since the given server knowledge,
+// returning them with the server knowledge as of this request. A server knowledge of 0 lists all of the scheduled transactions.
+func (c *Client) ListScheduledTransactionsSince(ctx context.Context, budgetID string, lastKnowledgeOfServer int) ([]Changed[ScheduledTransactionDetail], int, error) {
+	response, err := parseResponse[ynabapi.ScheduledTransactionsResponse](c.api.GetScheduledTransactions(ctx, budgetID, &ynabapi.GetScheduledTransactionsParams{
+		LastKnowledgeOfServer: knowledgeParam(lastKnowledgeOfServer),
+	}))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list scheduled transactions since server knowledge %d: %w", lastKnowledgeOfServer, err)
+	}
+
+	return toChanges(response.Data.ScheduledTransactions, func(scheduledTransaction ScheduledTransactionDetail) bool {
+		return scheduledTransaction.Deleted
+	}), response.Data.ServerKnowledge, nil
+}
+
+// knowledgeParam returns the last_knowledge_of_server parameter for the given server knowledge,
+// which is omitted when there is none so that all of the entities are listed.
+func knowledgeParam(lastKnowledgeOfServer int) *int {
+	if lastKnowledgeOfServer <= 0 {
+		return nil
+	}
+
+	return &lastKnowledgeOfServer
+}
+
+// toChanges describes the entities returned by a delta request as changes.
+func toChanges[T any](entities []T, isDeleted func(T) bool) []Changed[T] {
+	changes := make([]Changed[T], len(entities))
+	for entityIndex, entity := range entities {
+		changes[entityIndex] = Changed[T]{
+			Entity:  entity,
+			Deleted: isDeleted(entity),
+		}
+	}
+
+	return changes
+}
@@ -7,6 +7,7 @@ import "fmt"
 type MinimumBalanceAdjustment struct {
 	Dollars int
 	Cents   int
+	Rule    string // a description of the rule that determined the minimum balance; empty if not known
 }
 
 // ToCents expresses the amount in just cents.
@@ -0,0 +1,74 @@
+package ynab
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/ynab/ynabapi"
+)
+
+// The types below are those generated from YNAB's OpenAPI spec. Their fields are named after the API's JSON properties,
+// and all amounts are in milliunits.
+
+// BudgetSummary is a budget to which the access token has access.
+type BudgetSummary = ynabapi.BudgetSummary
+
+// DateFormat is the format in which a budget displays dates.
+type DateFormat = ynabapi.DateFormat
+
+// CurrencyFormat is the format in which a budget displays amounts.
+type CurrencyFormat = ynabapi.CurrencyFormat
+
+// Account is an account in a budget.
+type Account = ynabapi.Account
+
+// Payee is a payee in a budget.
+type Payee = ynabapi.Payee
+
+// CategoryGroup is a group of categories in a budget, with its categories.
+type CategoryGroup = ynabapi.CategoryGroupWithCategories
+
+// Category is a category in a budget, with its amounts for the current month.
+type Category = ynabapi.Category
+
+// TransactionSummary is a transaction in an account.
+type TransactionSummary = ynabapi.TransactionSummary
+
+// TransactionDetail is a transaction in an account, with the names of its account, payee, and category and its split.
+type TransactionDetail = ynabapi.TransactionDetail
+
+// SubTransaction is a portion of a split transaction.
+type SubTransaction = ynabapi.SubTransaction
+
+// SaveTransaction is a transaction to be created, with the import ID by which YNAB recognizes it if it is posted again.
+type SaveTransaction = ynabapi.NewTransaction
+
+// SaveTransactionFields are the fields of a transaction to be created.
+type SaveTransactionFields = ynabapi.SaveTransactionWithOptionalFields
+
+// ScheduledTransactionSummary is a transaction scheduled to recur in an account.
+type ScheduledTransactionSummary = ynabapi.ScheduledTransactionSummary
+
+// ScheduledTransactionDetail is a scheduled transaction, with the names of its account, payee, and category and its split.
+type ScheduledTransactionDetail = ynabapi.ScheduledTransactionDetail
+
+// ScheduledSubTransaction is a portion of a split scheduled transaction.
+type ScheduledSubTransaction = ynabapi.ScheduledSubTransaction
+
+// SaveScheduledTransaction is a scheduled transaction to be created.
+type SaveScheduledTransaction = ynabapi.SaveScheduledTransaction
+
+// ErrorDetail describes why a request to the API failed.
+type ErrorDetail = ynabapi.ErrorDetail
+
+// ErrorResponse is a response from the API reporting that a request failed.
+type ErrorResponse struct {
+	Response     *http.Response
+	ErrorDetails ErrorDetail `json:"error"`
+}
+
+func (r *ErrorResponse) Error() string {
+	return fmt.Sprintf("%s %s: %d %s %s",
+		r.Response.Request.Method, r.Response.Request.URL,
+		r.Response.StatusCode, r.ErrorDetails.Name, r.ErrorDetails.Detail)
+}
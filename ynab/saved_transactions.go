@@ -0,0 +1,41 @@
+package ynab
+
+import (
+	"slices"
+)
+
+// UncreatedTransaction is a transaction that was sent to YNAB to be created, but was not.
+type UncreatedTransaction struct {
+	Transaction SaveTransaction
+	Reason      string // why the transaction was not created
+}
+
+// FindUncreatedTransactions returns the given transactions that are not among the saved transactions, matched by their import IDs.
+func FindUncreatedTransactions(transactions []SaveTransaction, saved *SavedTransactions) []UncreatedTransaction {
+	createdImportIDs := make(map[string]bool, len(saved.Transactions))
+	for _, savedTransaction := range saved.Transactions {
+		if savedTransaction.ImportId != nil {
+			createdImportIDs[*savedTransaction.ImportId] = true
+		}
+	}
+
+	var uncreated []UncreatedTransaction
+	for _, transaction := range transactions {
+		switch {
+		case createdImportIDs[transaction.ImportId]:
+			continue
+		case slices.Contains(saved.DuplicateImportIDs, transaction.ImportId):
+			uncreated = append(uncreated, UncreatedTransaction{
+				Transaction: transaction,
+				Reason:      "a transaction with the same import ID already exists",
+			})
+		default:
+			uncreated = append(uncreated, UncreatedTransaction{
+				Transaction: transaction,
+				Reason:      "YNAB did not return it as created",
+			})
+		}
+	}
+
+	return uncreated
+}
@@ -0,0 +1,48 @@
+package ynab_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("SavedTransactions", func() {
+	Context("FindUncreatedTransactions", func() {
+		It("returns the transactions that were not created, with the reason why", func() {
+			createdImportID := cliynab.ImportID("run-0", 0)
+
+			transactions := []cliynab.SaveTransaction{
+				{SaveTransactionWithOptionalFields: cliynab.SaveTransactionFields{AccountId: "account-0"}, ImportId: createdImportID},
+				{SaveTransactionWithOptionalFields: cliynab.SaveTransactionFields{AccountId: "account-1"}, ImportId: cliynab.ImportID("run-0", 1)},
+				{SaveTransactionWithOptionalFields: cliynab.SaveTransactionFields{AccountId: "account-2"}, ImportId: cliynab.ImportID("run-0", 2)},
+			}
+
+			uncreated := cliynab.FindUncreatedTransactions(transactions, &cliynab.SavedTransactions{
+				Transactions: []cliynab.TransactionDetail{
+					{TransactionSummary: cliynab.TransactionSummary{Id: "transaction-0", ImportId: &createdImportID}},
+				},
+				DuplicateImportIDs: []string{cliynab.ImportID("run-0", 1)},
+			})
+
+			Expect(uncreated).To(HaveLen(2), "the two transactions that were not created should be returned")
+			Expect(uncreated[0].Transaction.AccountId).To(Equal("account-1"), "the duplicate transaction should be returned")
+			Expect(uncreated[0].Reason).To(ContainSubstring("same import ID"), "the duplicate should be explained")
+			Expect(uncreated[1].Transaction.AccountId).To(Equal("account-2"), "the transaction missing from the response should be returned")
+			Expect(uncreated[1].Reason).To(ContainSubstring("did not return"), "the missing transaction should be explained")
+		})
+
+		It("returns nothing if every transaction was created", func() {
+			importID := cliynab.ImportID("run-0", 0)
+
+			Expect(cliynab.FindUncreatedTransactions(
+				[]cliynab.SaveTransaction{{ImportId: importID}},
+				&cliynab.SavedTransactions{
+					Transactions: []cliynab.TransactionDetail{
+						{TransactionSummary: cliynab.TransactionSummary{Id: "transaction-0", ImportId: &importID}},
+					},
+				},
+			)).To(BeEmpty(), "no transactions should be uncreated")
+		})
+	})
+})
@@ -2,44 +2,68 @@ package ynab
 
 import (
 	"fmt"
-	"time"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 )
 
 // IsScheduledAfterInclusive returns true if the scheduled transaction is scheduled for after the given date.
 func IsScheduledAfterInclusive(
-	scheduledTransactionSummary ynab.ScheduledTransactionSummary,
-	dateTime time.Time,
+	scheduledTransactionSummary ScheduledTransactionSummary,
+	date civil.Date,
 ) (bool, error) {
-	nextDate, err := parseScheduledDate(scheduledTransactionSummary)
+	nextDate, err := ParseScheduledDate(scheduledTransactionSummary)
 	if err != nil {
 		return false, err
 	}
 
-	return nextDate.Equal(dateTime) || nextDate.After(dateTime), nil
+	return !nextDate.Before(date), nil
 }
 
 // IsScheduledBeforeInclusive returns true if the scheduled transaction is scheduled for before the given date.
 func IsScheduledBeforeInclusive(
-	scheduledTransactionSummary ynab.ScheduledTransactionSummary,
-	dateTime time.Time,
+	scheduledTransactionSummary ScheduledTransactionSummary,
+	date civil.Date,
 ) (bool, error) {
-	nextDate, err := parseScheduledDate(scheduledTransactionSummary)
+	nextDate, err := ParseScheduledDate(scheduledTransactionSummary)
 	if err != nil {
 		return false, err
 	}
 
-	return nextDate.Equal(dateTime) || nextDate.Before(dateTime), nil
+	return !nextDate.After(date), nil
 }
 
-func parseScheduledDate(
-	scheduledTransactionSummary ynab.ScheduledTransactionSummary,
-) (time.Time, error) {
-	nextDate, err := time.Parse(time.DateOnly, scheduledTransactionSummary.DateNext)
+// ParseScheduledDate returns the date for which the scheduled transaction is next scheduled.
+func ParseScheduledDate(
+	scheduledTransactionSummary ScheduledTransactionSummary,
+) (civil.Date, error) {
+	nextDate, err := civil.ParseDate(scheduledTransactionSummary.DateNext)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse 'date next' value of '%s': %w", scheduledTransactionSummary.DateNext, err)
+		return civil.Date{}, fmt.Errorf("failed to parse 'date next' value of '%s': %w", scheduledTransactionSummary.DateNext, err)
 	}
 
 	return nextDate, nil
 }
+
+// ToScheduledTransaction represents an already-entered transaction as a scheduled transaction
+// that is next scheduled for the transaction's date and does not repeat.
+// This allows future-dated transactions to be projected alongside scheduled transactions.
+func ToScheduledTransaction(transaction TransactionDetail) ScheduledTransactionDetail {
+	return ScheduledTransactionDetail{
+		ScheduledTransactionSummary: ScheduledTransactionSummary{
+			Id:                transaction.Id,
+			DateFirst:         transaction.Date,
+			DateNext:          transaction.Date,
+			Frequency:         "never",
+			Amount:            transaction.Amount,
+			Memo:              transaction.Memo,
+			FlagColor:         transaction.FlagColor,
+			AccountId:         transaction.AccountId,
+			PayeeId:           transaction.PayeeId,
+			CategoryId:        transaction.CategoryId,
+			TransferAccountId: transaction.TransferAccountId,
+		},
+		AccountName:  transaction.AccountName,
+		PayeeName:    transaction.PayeeName,
+		CategoryName: transaction.CategoryName,
+	}
+}
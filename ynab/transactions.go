@@ -1,26 +1,32 @@
 package ynab
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
-	"time"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/currency"
+	"github.com/jrh3k5/cryptonabber-offramp/v3/logging"
 )
 
 // CreateTransactions creates all of the necessary transactions to record the transfers between accounts.
+// Funds are recorded as leaving the funds origin account on the clock's current date and as arriving in the offramp accounts on the given arrival date.
 func CreateTransactions(
+	ctx context.Context,
+	clock civil.Clock,
 	fundsOriginAccountID string,
 	recipientAccountID string,
 	outboundBalancesByAccountID map[string]*OutboundTransactionBalance,
 	balanceAdjustmentsByAccountID map[string]*MinimumBalanceAdjustment,
 	accountNamesByID map[string]string,
 	payeeIDsByAccountID map[string]string, // mapping account ID to the payee ID to use to write a transfer to that account
-	startDate time.Time,
-	endDate time.Time,
-) ([]ynab.SaveTransaction, error) {
-	nowDate := time.Now().Format(time.DateOnly)
+	startDate civil.Date,
+	endDate civil.Date,
+	arrivalDate civil.Date,
+) ([]SaveTransaction, error) {
+	nowDate := civil.Today(clock).String()
 
 	uniqueAccountIDs := make(map[string]any)
 
@@ -49,13 +55,15 @@ func CreateTransactions(
 		return nil, fmt.Errorf("unable to resolve transfer payee ID to receive funds at recipient account ID '%s'", recipientAccountID)
 	}
 
-	transactions := []ynab.SaveTransaction{
+	transactions := []SaveTransaction{
 		{
-			AccountId: fundsOriginAccountID,
-			PayeeId:   recipientAccountPayeeID,
-			Amount:    sumCents * -10,
-			Date:      nowDate,
-			Memo:      buildSummaryMemo(startDate, endDate, outboundBalancesByAccountID, balanceAdjustmentsByAccountID, accountNamesByID),
+			SaveTransactionWithOptionalFields: SaveTransactionFields{
+				AccountId: fundsOriginAccountID,
+				PayeeId:   recipientAccountPayeeID,
+				Amount:    -currency.CentsToMilliunits(sumCents),
+				Date:      nowDate,
+				Memo:      buildSummaryMemo(startDate, endDate, outboundBalancesByAccountID, balanceAdjustmentsByAccountID, accountNamesByID),
+			},
 		},
 	}
 
@@ -83,19 +91,97 @@ func CreateTransactions(
 			continue
 		}
 
-		transactions = append(transactions, ynab.SaveTransaction{
-			AccountId: offrampAccountID,
-			PayeeId:   recipientAccountPayeeID,
-			Amount:    totalTransfer * 10,
-			Date:      nowDate,
-			Memo:      buildBasicTransferMemo(startDate, endDate, balanceAdjustment),
+		transactions = append(transactions, SaveTransaction{
+			SaveTransactionWithOptionalFields: SaveTransactionFields{
+				AccountId: offrampAccountID,
+				PayeeId:   recipientAccountPayeeID,
+				Amount:    currency.CentsToMilliunits(totalTransfer),
+				Date:      arrivalDate.String(),
+				Memo:      buildBasicTransferMemo(startDate, endDate, balanceAdjustment),
+			},
 		})
 	}
 
+	logger := logging.FromContext(ctx)
+	for _, transaction := range transactions {
+		logger.DebugContext(ctx, "built transfer transaction",
+			"account", accountNamesByID[transaction.AccountId],
+			"amount_milliunits", transaction.Amount,
+			"date", transaction.Date,
+			"memo", transaction.Memo)
+	}
+
+	return transactions, nil
+}
+
+// ImportID returns the import ID of the transaction at the given index among those created by the given run.
+// YNAB rejects a transaction whose import ID has already been used in the same account, so posting a run's transactions twice does not duplicate them.
+func ImportID(runID string, index int) string {
+	return fmt.Sprintf("offramp:%s:%d", runID, index)
+}
+
+// CreateSweepTransactions creates the transactions to record moving excess balances out of accounts
+// that would otherwise exceed their maximum balance. These move funds in the opposite direction
+// from the transactions created by CreateTransactions.
+func CreateSweepTransactions(
+	ctx context.Context,
+	clock civil.Clock,
+	sweepsByAccountID map[string]*BalanceSweep,
+	accountNamesByID map[string]string,
+	payeeIDsByAccountID map[string]string, // mapping account ID to the payee ID to use to write a transfer to that account
+	startDate civil.Date,
+	endDate civil.Date,
+) ([]SaveTransaction, error) {
+	nowDate := civil.Today(clock).String()
+
+	// Get some kind of consistency in ordering, if just to help tests
+	accountIDs := make([]string, 0, len(sweepsByAccountID))
+	for accountID := range sweepsByAccountID {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	logger := logging.FromContext(ctx)
+
+	var transactions []SaveTransaction
+	for _, accountID := range accountIDs {
+		sweep := sweepsByAccountID[accountID]
+		if sweep.ToCents() == 0 {
+			continue
+		}
+
+		if sweep.DestinationAccountID == accountID {
+			return nil, fmt.Errorf("account ID '%s' cannot sweep its excess balance to itself", accountID)
+		}
+
+		destinationPayeeID, hasID := payeeIDsByAccountID[sweep.DestinationAccountID]
+		if !hasID {
+			return nil, fmt.Errorf("unable to resolve transfer payee ID to sweep funds into account ID '%s'", sweep.DestinationAccountID)
+		}
+
+		transaction := SaveTransaction{
+			SaveTransactionWithOptionalFields: SaveTransactionFields{
+				AccountId: accountID,
+				PayeeId:   destinationPayeeID,
+				Amount:    -currency.CentsToMilliunits(sweep.ToCents()),
+				Date:      nowDate,
+				Memo:      fmt.Sprintf("Sweep of balance over maximum after bills %s - %s", startDate.Format("01/02"), endDate.Format("01/02")),
+			},
+		}
+
+		logger.DebugContext(ctx, "built sweep transaction",
+			"account", accountNamesByID[accountID],
+			"destination_account", accountNamesByID[sweep.DestinationAccountID],
+			"amount_milliunits", transaction.Amount,
+			"date", transaction.Date)
+
+		transactions = append(transactions, transaction)
+	}
+
 	return transactions, nil
 }
 
-func buildBasicTransferMemo(startDate, endDate time.Time, minimumBalanceAdjustment *MinimumBalanceAdjustment) string {
+func buildBasicTransferMemo(startDate, endDate civil.Date, minimumBalanceAdjustment *MinimumBalanceAdjustment) string {
 	memoString := fmt.Sprintf("Bills %s - %s", startDate.Format("01/02"), endDate.Format("01/02"))
 	if minimumBalanceAdjustment != nil {
 		memoString += fmt.Sprintf(" (minimum balance adjustment: %s)", minimumBalanceAdjustment.String())
@@ -105,8 +191,8 @@ func buildBasicTransferMemo(startDate, endDate time.Time, minimumBalanceAdjustme
 }
 
 func buildSummaryMemo(
-	startDate time.Time,
-	endDate time.Time,
+	startDate civil.Date,
+	endDate civil.Date,
 	outboundBalancesByAccountID map[string]*OutboundTransactionBalance,
 	minimumBalanceAdjustmentsByAccountID map[string]*MinimumBalanceAdjustment,
 	accountNamesByID map[string]string,
@@ -1,12 +1,12 @@
 package ynab_test
 
 import (
-	"time"
+	"context"
 
-	"github.com/davidsteinsland/ynab-go/ynab"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
 )
 
@@ -21,10 +21,14 @@ var _ = Describe("Transactions", func() {
 		var balanceAdjustmentsByAccountID map[string]*cliynab.MinimumBalanceAdjustment
 		var namesByID map[string]string
 		var payeesByAccountID map[string]string
-		var startDate time.Time
-		var endDate time.Time
+		var startDate civil.Date
+		var endDate civil.Date
+		var arrivalDate civil.Date
+		var ctx context.Context
 
 		BeforeEach(func() {
+			ctx = context.Background()
+
 			fundsOriginAccountID = "funds-origin"
 			fundsRecipientAccountID = "funds-recipient"
 
@@ -58,8 +62,9 @@ var _ = Describe("Transactions", func() {
 
 			balanceAdjustmentsByAccountID = map[string]*cliynab.MinimumBalanceAdjustment{}
 
-			startDate, _ = time.Parse(time.DateOnly, "2024-02-01")
-			endDate, _ = time.Parse(time.DateOnly, "2024-02-03")
+			startDate, _ = civil.ParseDate("2024-02-01")
+			endDate, _ = civil.ParseDate("2024-02-03")
+			arrivalDate, _ = civil.ParseDate("2024-01-30")
 		})
 
 		It("creates the transactions to transfer funds", func() {
@@ -67,14 +72,16 @@ var _ = Describe("Transactions", func() {
 			// sanity check
 			Expect(recipientAccountPayeeID).ToNot(BeEmpty(), "there should be a payee ID for the recipient account set up")
 
-			transactions, err := cliynab.CreateTransactions(fundsOriginAccountID,
+			transactions, err := cliynab.CreateTransactions(ctx, clock,
+				fundsOriginAccountID,
 				fundsRecipientAccountID,
 				outboundBalances,
 				balanceAdjustmentsByAccountID,
 				namesByID,
 				payeesByAccountID,
 				startDate,
-				endDate)
+				endDate,
+				arrivalDate)
 
 			Expect(err).ToNot(HaveOccurred(), "creating the transactions should not fail")
 			Expect(transactions).To(HaveLen(3), "the correct number of transactions should be created")
@@ -90,6 +97,10 @@ var _ = Describe("Transactions", func() {
 			offramp1Transaction := getTransactionByAccountID(offrampAccountID1, transactions)
 			Expect(offramp1Transaction.Amount).To(Equal(420690), "offramp account 1 should be receiving its outbound amount")
 			Expect(offramp1Transaction.PayeeId).To(Equal(recipientAccountPayeeID), "the funds should be coming from the recipient account")
+
+			Expect(fundsOriginTransaction.Date).To(Equal("2024-01-29"), "the funds should be sent today")
+			Expect(offramp0Transaction.Date).To(Equal("2024-01-30"), "the funds should arrive in offramp account 0 on the arrival date")
+			Expect(offramp1Transaction.Date).To(Equal("2024-01-30"), "the funds should arrive in offramp account 1 on the arrival date")
 		})
 
 		When("there is a minimum balance adjustment for one of the accounts", func() {
@@ -109,14 +120,16 @@ var _ = Describe("Transactions", func() {
 				// sanity check
 				Expect(recipientAccountPayeeID).ToNot(BeEmpty(), "there should be a payee ID for the recipient account set up")
 
-				transactions, err := cliynab.CreateTransactions(fundsOriginAccountID,
+				transactions, err := cliynab.CreateTransactions(ctx, clock,
+					fundsOriginAccountID,
 					fundsRecipientAccountID,
 					outboundBalances,
 					balanceAdjustmentsByAccountID,
 					namesByID,
 					payeesByAccountID,
 					startDate,
-					endDate)
+					endDate,
+					arrivalDate)
 
 				Expect(err).ToNot(HaveOccurred(), "creating the transactions should not fail")
 				Expect(transactions).To(HaveLen(3), "the correct number of transactions should be created")
@@ -145,14 +158,16 @@ var _ = Describe("Transactions", func() {
 			})
 
 			It("adds it to the funds transfer, but does not generate a transfer between the recipient account and itself", func() {
-				transactions, err := cliynab.CreateTransactions(fundsOriginAccountID,
+				transactions, err := cliynab.CreateTransactions(ctx, clock,
+					fundsOriginAccountID,
 					fundsRecipientAccountID,
 					outboundBalances,
 					balanceAdjustmentsByAccountID,
 					namesByID,
 					payeesByAccountID,
 					startDate,
-					endDate)
+					endDate,
+					arrivalDate)
 
 				Expect(err).ToNot(HaveOccurred(), "creating the transactions should not fail")
 				Expect(transactions).To(HaveLen(3), "the correct number of transactions should be created")
@@ -169,14 +184,16 @@ var _ = Describe("Transactions", func() {
 				outboundBalances[offrampAccountID1].Cents = 0
 				outboundBalances[offrampAccountID1].Dollars = 0
 
-				transactions, err := cliynab.CreateTransactions(fundsOriginAccountID,
+				transactions, err := cliynab.CreateTransactions(ctx, clock,
+					fundsOriginAccountID,
 					fundsRecipientAccountID,
 					outboundBalances,
 					balanceAdjustmentsByAccountID,
 					namesByID,
 					payeesByAccountID,
 					startDate,
-					endDate)
+					endDate,
+					arrivalDate)
 
 				Expect(err).ToNot(HaveOccurred(), "creating the transactions should not fail")
 				Expect(transactions).To(HaveLen(2), "only accounts with a non-zero sum of transactions should be created")
@@ -193,14 +210,16 @@ var _ = Describe("Transactions", func() {
 				outboundBalances[offrampAccountID1].Cents = 0
 				outboundBalances[offrampAccountID1].Dollars = 0
 
-				transactions, err := cliynab.CreateTransactions(fundsOriginAccountID,
+				transactions, err := cliynab.CreateTransactions(ctx, clock,
+					fundsOriginAccountID,
 					fundsRecipientAccountID,
 					outboundBalances,
 					balanceAdjustmentsByAccountID,
 					namesByID,
 					payeesByAccountID,
 					startDate,
-					endDate)
+					endDate,
+					arrivalDate)
 
 				Expect(err).ToNot(HaveOccurred(), "creating the transactions should not fail")
 
@@ -212,7 +231,80 @@ var _ = Describe("Transactions", func() {
 	})
 })
 
-func getTransactionByAccountID(accountID string, transactions []ynab.SaveTransaction) ynab.SaveTransaction {
+var _ = Describe("SweepTransactions", func() {
+	Context("CreateSweepTransactions", func() {
+		var ctx context.Context
+		var namesByID map[string]string
+		var payeesByAccountID map[string]string
+		var startDate civil.Date
+		var endDate civil.Date
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			namesByID = map[string]string{
+				"checking": "Checking",
+				"savings":  "Savings",
+				"other":    "Other",
+			}
+
+			payeesByAccountID = map[string]string{
+				"checking": "payee-checking",
+				"savings":  "payee-savings",
+				"other":    "payee-other",
+			}
+
+			startDate, _ = civil.ParseDate("2024-02-01")
+			endDate, _ = civil.ParseDate("2024-02-03")
+		})
+
+		It("creates transfers out of the accounts with excess balances", func() {
+			transactions, err := cliynab.CreateSweepTransactions(ctx, clock,
+				map[string]*cliynab.BalanceSweep{
+					"checking": {
+						Dollars:              12,
+						Cents:                34,
+						DestinationAccountID: "savings",
+					},
+					"other": {
+						DestinationAccountID: "savings",
+					},
+				},
+				namesByID,
+				payeesByAccountID,
+				startDate,
+				endDate)
+
+			Expect(err).ToNot(HaveOccurred(), "creating the sweep transactions should not fail")
+			Expect(transactions).To(HaveLen(1), "only accounts with an excess balance should be swept")
+
+			checkingTransaction := getTransactionByAccountID("checking", transactions)
+			Expect(checkingTransaction.Amount).To(Equal(-12340), "the excess should be moved out of the account")
+			Expect(checkingTransaction.PayeeId).To(Equal("payee-savings"), "the excess should be transferred to the destination account")
+			Expect(checkingTransaction.Date).To(Equal("2024-01-29"), "the excess should be moved today")
+		})
+
+		When("the destination account has no known transfer payee", func() {
+			It("returns an error", func() {
+				_, err := cliynab.CreateSweepTransactions(ctx, clock,
+					map[string]*cliynab.BalanceSweep{
+						"checking": {
+							Dollars:              1,
+							DestinationAccountID: "unknown",
+						},
+					},
+					namesByID,
+					payeesByAccountID,
+					startDate,
+					endDate)
+
+				Expect(err).To(HaveOccurred(), "an unresolvable destination should fail")
+			})
+		})
+	})
+})
+
+func getTransactionByAccountID(accountID string, transactions []cliynab.SaveTransaction) cliynab.SaveTransaction {
 	matches := getTransactionsByAccountID(accountID, transactions)
 
 	Expect(matches).To(HaveLen(1), "there should only be one transaction for account ID '%s'", accountID)
@@ -220,8 +312,8 @@ func getTransactionByAccountID(accountID string, transactions []ynab.SaveTransac
 	return matches[0]
 }
 
-func getTransactionsByAccountID(accountID string, transactions []ynab.SaveTransaction) []ynab.SaveTransaction {
-	var matches []ynab.SaveTransaction
+func getTransactionsByAccountID(accountID string, transactions []cliynab.SaveTransaction) []cliynab.SaveTransaction {
+	var matches []cliynab.SaveTransaction
 	for _, transaction := range transactions {
 		if transaction.AccountId == accountID {
 			matches = append(matches, transaction)
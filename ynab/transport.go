@@ -0,0 +1,264 @@
+package ynab
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultMaxRetries     = 3
+	defaultInitialBackoff = time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMaxRetryAfter  = 2 * time.Minute
+	defaultAttemptTimeout = 30 * time.Second
+
+	// rateLimitHeader is the header in which YNAB reports the requests made and allowed in the current hour, such as "36/200".
+	rateLimitHeader  = "X-Rate-Limit"
+	retryAfterHeader = "Retry-After"
+)
+
+// RetryingTransport is an http.RoundTripper for requests to the YNAB API that bounds how long each attempt may take,
+// retries transient failures with exponential backoff, and honors the Retry-After header of rate-limited (429) responses.
+// It counts the requests it makes so that they can be compared to YNAB's limit of 200 requests per hour per access token.
+type RetryingTransport struct {
+	Base           http.RoundTripper // the transport that makes each attempt
+	Logger         *slog.Logger      // the logger to which each request is logged at the debug level
+	MaxRetries     int               // the number of times a failed request is retried
+	InitialBackoff time.Duration     // the wait before the first retry, doubled for each subsequent retry
+	MaxBackoff     time.Duration     // the longest wait between retries, other than one requested by Retry-After
+	MaxRetryAfter  time.Duration     // the longest Retry-After that is waited out; a rate-limited response asking for longer is returned as-is
+	AttemptTimeout time.Duration     // the longest each attempt, including reading its response, may take
+
+	requestCount atomic.Int64
+}
+
+var _ http.RoundTripper = (*RetryingTransport)(nil)
+
+// NewRetryingTransport creates a RetryingTransport with default limits that makes its attempts using the given transport.
+func NewRetryingTransport(base http.RoundTripper, logger *slog.Logger) *RetryingTransport {
+	return &RetryingTransport{
+		Base:           base,
+		Logger:         logger,
+		MaxRetries:     defaultMaxRetries,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		MaxRetryAfter:  defaultMaxRetryAfter,
+		AttemptTimeout: defaultAttemptTimeout,
+	}
+}
+
+// RequestCount returns the number of requests, including retries, that have been made.
+func (r *RetryingTransport) RequestCount() int {
+	return int(r.requestCount.Load())
+}
+
+// RoundTrip makes the given request, retrying it if it fails transiently.
+// Requests that are not idempotent, such as those creating transactions, are retried only if they were rate-limited,
+// as the API will not have acted upon them.
+func (r *RetryingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptRequest, err := r.prepareAttempt(request, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		response, cancelAttempt, err := r.attempt(attemptRequest)
+
+		wait, retry := r.shouldRetry(request, response, err, attempt)
+		if !retry {
+			if err != nil {
+				cancelAttempt()
+				return nil, err
+			}
+
+			// the attempt's timeout covers reading the response body, so it is released only once the body is closed
+			response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancelAttempt}
+
+			return response, nil
+		}
+
+		if response != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		cancelAttempt()
+
+		r.Logger.WarnContext(ctx, "Retrying YNAB API request",
+			"method", request.Method,
+			"path", request.URL.Path,
+			"attempt", attempt+1,
+			"wait", wait.String(),
+			"reason", describeFailure(response, err))
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// prepareAttempt returns the request to be made for the given attempt, with a fresh copy of the request's body for any retry.
+func (r *RetryingTransport) prepareAttempt(request *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || request.Body == nil || request.Body == http.NoBody {
+		return request, nil
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy request body for retry: %w", err)
+	}
+
+	attemptRequest := request.Clone(request.Context())
+	attemptRequest.Body = body
+
+	return attemptRequest, nil
+}
+
+// attempt makes a single attempt at the given request, bounded by the attempt timeout.
+// The returned function releases the attempt's timeout and must be called once the response is no longer needed.
+func (r *RetryingTransport) attempt(request *http.Request) (*http.Response, context.CancelFunc, error) {
+	attemptCtx, cancel := context.WithTimeout(request.Context(), r.AttemptTimeout)
+
+	response, err := r.Base.RoundTrip(request.WithContext(attemptCtx))
+
+	requestCount := r.requestCount.Add(1)
+
+	logAttributes := []any{
+		"method", request.Method,
+		"path", request.URL.Path,
+		"requests_made", requestCount,
+	}
+	if response != nil {
+		logAttributes = append(logAttributes, "status", response.StatusCode)
+
+		if rateLimit := response.Header.Get(rateLimitHeader); rateLimit != "" {
+			logAttributes = append(logAttributes, "rate_limit", rateLimit)
+		}
+	}
+	r.Logger.DebugContext(request.Context(), "YNAB API request", logAttributes...)
+
+	return response, cancel, err
+}
+
+// shouldRetry returns how long to wait before retrying the request and true if the given outcome of an attempt is to be retried.
+func (r *RetryingTransport) shouldRetry(request *http.Request, response *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt >= r.MaxRetries {
+		return 0, false
+	}
+
+	// the request cannot be retried if its body cannot be sent again
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return 0, false
+	}
+
+	backoff := r.InitialBackoff << attempt
+	if backoff > r.MaxBackoff {
+		backoff = r.MaxBackoff
+	}
+
+	if err != nil {
+		// the caller gave up on the request, so it should not be retried
+		if request.Context().Err() != nil {
+			return 0, false
+		}
+
+		return backoff, isIdempotent(request.Method)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		retryAfter, hasRetryAfter := parseRetryAfter(response.Header.Get(retryAfterHeader), time.Now())
+		if !hasRetryAfter {
+			return backoff, true
+		}
+
+		if retryAfter > r.MaxRetryAfter {
+			r.Logger.WarnContext(request.Context(), "The YNAB API rate limit has been exceeded; try again later",
+				"retry_after", retryAfter.String(),
+				"requests_made", r.RequestCount())
+			return 0, false
+		}
+
+		return retryAfter, true
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return backoff, isIdempotent(request.Method)
+	default:
+		return 0, false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date,
+// into how long to wait after the given time.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	retryAt, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if wait := retryAt.Sub(now); wait > 0 {
+		return wait, true
+	}
+
+	return 0, true
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func describeFailure(response *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+
+	return response.Status
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelOnClose releases an attempt's timeout once the response body it covers is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+
+	return c.ReadCloser.Close()
+}
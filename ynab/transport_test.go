@@ -0,0 +1,145 @@
+package ynab_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	cliynab "github.com/jrh3k5/cryptonabber-offramp/v3/ynab"
+)
+
+var _ = Describe("RetryingTransport", func() {
+	var server *httptest.Server
+	var responses []func(w http.ResponseWriter)
+	var requestBodies []string
+	var transport *cliynab.RetryingTransport
+	var httpClient *http.Client
+
+	BeforeEach(func() {
+		responses = nil
+		requestBodies = nil
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestBody, _ := io.ReadAll(r.Body)
+			requestBodies = append(requestBodies, string(requestBody))
+
+			respond := responses[0]
+			if len(responses) > 1 {
+				responses = responses[1:]
+			}
+			respond(w)
+		}))
+		DeferCleanup(server.Close)
+
+		transport = cliynab.NewRetryingTransport(server.Client().Transport, slog.New(slog.DiscardHandler))
+		transport.InitialBackoff = time.Millisecond
+		transport.MaxBackoff = time.Millisecond
+		httpClient = &http.Client{Transport: transport}
+	})
+
+	respondWith := func(status int, headers ...string) func(w http.ResponseWriter) {
+		return func(w http.ResponseWriter) {
+			for headerIndex := 0; headerIndex < len(headers); headerIndex += 2 {
+				w.Header().Set(headers[headerIndex], headers[headerIndex+1])
+			}
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}
+
+	It("retries a request that fails transiently", func() {
+		responses = append(responses, respondWith(http.StatusServiceUnavailable), respondWith(http.StatusOK))
+
+		response, err := httpClient.Get(server.URL + "/v1/budgets")
+		Expect(err).ToNot(HaveOccurred(), "the request should succeed after retrying")
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusOK), "the successful response should be returned")
+		body, err := io.ReadAll(response.Body)
+		Expect(err).ToNot(HaveOccurred(), "the response body should be readable after the request returns")
+		Expect(string(body)).To(Equal(`{"data":{}}`), "the response body should be returned")
+		Expect(transport.RequestCount()).To(Equal(2), "both attempts should be counted")
+	})
+
+	It("waits out the Retry-After of a rate-limited request and resends its body", func() {
+		responses = append(responses, respondWith(http.StatusTooManyRequests, "Retry-After", "1"), respondWith(http.StatusCreated))
+
+		start := time.Now()
+		response, err := httpClient.Post(server.URL+"/v1/budgets/budget-0/transactions", "application/json", strings.NewReader(`{"transactions":[]}`))
+		Expect(err).ToNot(HaveOccurred(), "the request should succeed after retrying")
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusCreated), "the successful response should be returned")
+		Expect(time.Since(start)).To(BeNumerically(">=", time.Second), "the Retry-After should be waited out")
+		Expect(requestBodies).To(Equal([]string{`{"transactions":[]}`, `{"transactions":[]}`}), "the body should be sent with each attempt")
+	})
+
+	It("does not wait out a Retry-After longer than the maximum", func() {
+		responses = append(responses, respondWith(http.StatusTooManyRequests, "Retry-After", "3600"))
+
+		response, err := httpClient.Get(server.URL + "/v1/budgets")
+		Expect(err).ToNot(HaveOccurred(), "the rate-limited response should be returned")
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusTooManyRequests), "the rate-limited response should be returned")
+		Expect(transport.RequestCount()).To(Equal(1), "the request should not be retried")
+	})
+
+	It("does not retry a request that is not idempotent after a server error", func() {
+		responses = append(responses, respondWith(http.StatusInternalServerError), respondWith(http.StatusCreated))
+
+		response, err := httpClient.Post(server.URL+"/v1/budgets/budget-0/transactions", "application/json", strings.NewReader(`{}`))
+		Expect(err).ToNot(HaveOccurred(), "the server error should be returned")
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusInternalServerError), "the server error should be returned")
+		Expect(transport.RequestCount()).To(Equal(1), "the request should not be retried, as it may have been acted upon")
+	})
+
+	It("gives up after the maximum number of retries", func() {
+		responses = append(responses, respondWith(http.StatusBadGateway))
+
+		response, err := httpClient.Get(server.URL + "/v1/budgets")
+		Expect(err).ToNot(HaveOccurred(), "the last failed response should be returned")
+		defer response.Body.Close()
+
+		Expect(response.StatusCode).To(Equal(http.StatusBadGateway), "the last failed response should be returned")
+		Expect(transport.RequestCount()).To(Equal(4), "the request should be attempted once and retried three times")
+	})
+
+	It("times out an attempt that takes too long", func() {
+		responses = append(responses, func(w http.ResponseWriter) {
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		})
+		transport.AttemptTimeout = 50 * time.Millisecond
+		transport.MaxRetries = 0
+
+		_, err := httpClient.Get(server.URL + "/v1/budgets")
+		Expect(err).To(HaveOccurred(), "the request should time out")
+		Expect(err.Error()).To(ContainSubstring("deadline exceeded"), "the timeout should be reported")
+	})
+
+	It("stops retrying once the request's context is done", func() {
+		responses = append(responses, respondWith(http.StatusServiceUnavailable))
+		transport.InitialBackoff = time.Hour
+		transport.MaxBackoff = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v1/budgets", http.NoBody)
+		Expect(err).ToNot(HaveOccurred(), "building the request should not fail")
+
+		_, err = httpClient.Do(request)
+		Expect(err).To(HaveOccurred(), "the request should fail once its context is done")
+		Expect(transport.RequestCount()).To(Equal(1), "the request should not be retried after its context is done")
+	})
+})
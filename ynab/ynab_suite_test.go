@@ -2,11 +2,17 @@ package ynab_test
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/jrh3k5/cryptonabber-offramp/v3/civil"
 )
 
+// clock pins today for the tests of transactions dated today.
+var clock = civil.FixedClock{Time: time.Date(2024, time.January, 29, 18, 45, 0, 0, time.FixedZone("CST", -6*60*60))}
+
 func TestYnab(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Ynab Suite")
@@ -0,0 +1,5 @@
+package ynabapi
+
+// The client and models of this package are generated from YNAB's OpenAPI spec (openapi.yaml) by oapi-codegen,
+// with the types mapped onto Go types by overlay.yaml. The rest of this tool uses them through the ynab package's API interface.
+//go:generate go tool oapi-codegen -config config.yaml openapi.yaml
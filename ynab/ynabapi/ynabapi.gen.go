@@ -0,0 +1,2189 @@
+// Package ynabapi provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package ynabapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+const (
+	BearerScopes = "bearer.Scopes"
+)
+
+// Defines values for GetTransactionsByAccountParamsType.
+const (
+	Unapproved    GetTransactionsByAccountParamsType = "unapproved"
+	Uncategorized GetTransactionsByAccountParamsType = "uncategorized"
+)
+
+// Account defines model for Account.
+type Account struct {
+	// Balance The current balance of the account in milliunits format
+	Balance int `json:"balance"`
+
+	// ClearedBalance The current cleared balance of the account in milliunits format
+	ClearedBalance int `json:"cleared_balance"`
+
+	// Closed Whether this account is closed or not
+	Closed bool `json:"closed"`
+
+	// Deleted Whether or not the account has been deleted.  Deleted accounts will only be included in delta requests.
+	Deleted bool `json:"deleted"`
+
+	// DirectImportInError If an account linked to a financial institution (direct_import_linked=true) and the linked connection is not in a healthy state, this will be true.
+	DirectImportInError *bool `json:"direct_import_in_error,omitempty"`
+
+	// DirectImportLinked Whether or not the account is linked to a financial institution for automatic transaction import.
+	DirectImportLinked *bool  `json:"direct_import_linked,omitempty"`
+	Id                 string `json:"id"`
+
+	// LastReconciledAt A date/time specifying when the account was last reconciled.
+	LastReconciledAt *string `json:"last_reconciled_at"`
+	Name             string  `json:"name"`
+	Note             *string `json:"note"`
+
+	// OnBudget Whether this account is on budget or not
+	OnBudget bool `json:"on_budget"`
+
+	// TransferPayeeId The payee id which should be used when transferring to this account
+	TransferPayeeId *string `json:"transfer_payee_id"`
+
+	// Type The type of account
+	Type AccountType `json:"type"`
+
+	// UnclearedBalance The current uncleared balance of the account in milliunits format
+	UnclearedBalance int `json:"uncleared_balance"`
+}
+
+// AccountType The type of account
+type AccountType = string
+
+// AccountsResponse defines model for AccountsResponse.
+type AccountsResponse struct {
+	Data struct {
+		Accounts []Account `json:"accounts"`
+
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int `json:"server_knowledge"`
+	} `json:"data"`
+}
+
+// BudgetSummary defines model for BudgetSummary.
+type BudgetSummary struct {
+	// Accounts The budget accounts (only included if `include_accounts=true` specified as query parameter)
+	Accounts *[]Account `json:"accounts,omitempty"`
+
+	// CurrencyFormat The currency format setting for the budget.  In some cases the format will not be available and will be specified as null.
+	CurrencyFormat *CurrencyFormat `json:"currency_format"`
+
+	// DateFormat The date format setting for the budget.  In some cases the format will not be available and will be specified as null.
+	DateFormat *DateFormat `json:"date_format"`
+
+	// FirstMonth The earliest budget month
+	FirstMonth *string `json:"first_month,omitempty"`
+	Id         string  `json:"id"`
+
+	// LastModifiedOn The last time any changes were made to the budget from either a web or mobile client
+	LastModifiedOn *string `json:"last_modified_on,omitempty"`
+
+	// LastMonth The latest budget month
+	LastMonth *string `json:"last_month,omitempty"`
+	Name      string  `json:"name"`
+}
+
+// BudgetSummaryResponse defines model for BudgetSummaryResponse.
+type BudgetSummaryResponse struct {
+	Data struct {
+		Budgets       []BudgetSummary `json:"budgets"`
+		DefaultBudget *BudgetSummary  `json:"default_budget,omitempty"`
+	} `json:"data"`
+}
+
+// CategoriesResponse defines model for CategoriesResponse.
+type CategoriesResponse struct {
+	Data struct {
+		CategoryGroups []CategoryGroupWithCategories `json:"category_groups"`
+
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int `json:"server_knowledge"`
+	} `json:"data"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	// Activity Activity amount in milliunits format
+	Activity int `json:"activity"`
+
+	// Balance Balance in milliunits format
+	Balance int `json:"balance"`
+
+	// Budgeted Budgeted amount in milliunits format
+	Budgeted          int     `json:"budgeted"`
+	CategoryGroupId   string  `json:"category_group_id"`
+	CategoryGroupName *string `json:"category_group_name,omitempty"`
+
+	// Deleted Whether or not the category has been deleted.  Deleted categories will only be included in delta requests.
+	Deleted bool `json:"deleted"`
+
+	// Hidden Whether or not the category is hidden
+	Hidden bool    `json:"hidden"`
+	Id     string  `json:"id"`
+	Name   string  `json:"name"`
+	Note   *string `json:"note"`
+
+	// OriginalCategoryGroupId DEPRECATED No longer used.  Value will always be null.
+	OriginalCategoryGroupId *string `json:"original_category_group_id"`
+}
+
+// CategoryGroup defines model for CategoryGroup.
+type CategoryGroup struct {
+	// Deleted Whether or not the category group has been deleted.  Deleted category groups will only be included in delta requests.
+	Deleted bool `json:"deleted"`
+
+	// Hidden Whether or not the category group is hidden
+	Hidden bool   `json:"hidden"`
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+}
+
+// CategoryGroupWithCategories defines model for CategoryGroupWithCategories.
+type CategoryGroupWithCategories struct {
+	// Embedded struct due to allOf(#/components/schemas/CategoryGroup)
+	CategoryGroup `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	// Categories Category group categories.  Amounts (budgeted, activity, balance, etc.) are specific to the current budget month (UTC).
+	Categories []Category `json:"categories"`
+}
+
+// CurrencyFormat The currency format setting for the budget.  In some cases the format will not be available and will be specified as null.
+type CurrencyFormat struct {
+	CurrencySymbol   string `json:"currency_symbol"`
+	DecimalDigits    int    `json:"decimal_digits"`
+	DecimalSeparator string `json:"decimal_separator"`
+	DisplaySymbol    bool   `json:"display_symbol"`
+	ExampleFormat    string `json:"example_format"`
+	GroupSeparator   string `json:"group_separator"`
+	IsoCode          string `json:"iso_code"`
+	SymbolFirst      bool   `json:"symbol_first"`
+}
+
+// DateFormat The date format setting for the budget.  In some cases the format will not be available and will be specified as null.
+type DateFormat struct {
+	Format string `json:"format"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	Detail string `json:"detail"`
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// NewTransaction defines model for NewTransaction.
+type NewTransaction struct {
+	// Embedded struct due to allOf(#/components/schemas/SaveTransactionWithOptionalFields)
+	SaveTransactionWithOptionalFields `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	// ImportId If specified, a new transaction will be assigned this `import_id` and considered "imported".  We will also attempt to match this imported transaction to an existing "user-entered" transaction on the same account, with the same amount, and with a date +/-10 days from the imported transaction date.
+	ImportId string `json:"import_id,omitempty"`
+}
+
+// Payee defines model for Payee.
+type Payee struct {
+	// Deleted Whether or not the payee has been deleted.  Deleted payees will only be included in delta requests.
+	Deleted bool   `json:"deleted"`
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+
+	// TransferAccountId If a transfer payee, the `account_id` to which this payee transfers to
+	TransferAccountId *string `json:"transfer_account_id"`
+}
+
+// PayeesResponse defines model for PayeesResponse.
+type PayeesResponse struct {
+	Data struct {
+		Payees []Payee `json:"payees"`
+
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int `json:"server_knowledge"`
+	} `json:"data"`
+}
+
+// PostScheduledTransactionWrapper defines model for PostScheduledTransactionWrapper.
+type PostScheduledTransactionWrapper struct {
+	ScheduledTransaction SaveScheduledTransaction `json:"scheduled_transaction"`
+}
+
+// PostTransactionsWrapper defines model for PostTransactionsWrapper.
+type PostTransactionsWrapper struct {
+	Transaction  *NewTransaction   `json:"transaction,omitempty"`
+	Transactions *[]NewTransaction `json:"transactions,omitempty"`
+}
+
+// SaveScheduledTransaction defines model for SaveScheduledTransaction.
+type SaveScheduledTransaction struct {
+	AccountId string `json:"account_id,omitempty"`
+
+	// Amount The scheduled transaction amount in milliunits format.
+	Amount int `json:"amount,omitempty"`
+
+	// CategoryId The category for the scheduled transaction. Credit Card Payment categories are not permitted. Creating a split scheduled transaction is not currently supported.
+	CategoryId string `json:"category_id,omitempty"`
+
+	// Date The scheduled transaction date in ISO format (e.g. 2016-12-01).
+	Date string `json:"date,omitempty"`
+
+	// FlagColor The transaction flag
+	FlagColor *TransactionFlagColor `json:"flag_color"`
+
+	// Frequency The scheduled transaction frequency
+	Frequency *ScheduledTransactionFrequency `json:"frequency,omitempty"`
+	Memo      string                         `json:"memo,omitempty"`
+
+	// PayeeId The payee for the scheduled transaction.  To create a transfer between two accounts, use the account transfer payee pointing to the target account.  Account transfer payees are specified as `transfer_payee_id` on the account resource.
+	PayeeId string `json:"payee_id,omitempty"`
+
+	// PayeeName The payee name for the the scheduled transaction.  If a `payee_name` value is provided and `payee_id` has a null value, the `payee_name` value will be used to resolve the payee by either (1) a payee with the same name or (2) creation of a new payee.
+	PayeeName string `json:"payee_name,omitempty"`
+}
+
+// SaveTransactionWithOptionalFields defines model for SaveTransactionWithOptionalFields.
+type SaveTransactionWithOptionalFields struct {
+	AccountId string `json:"account_id,omitempty"`
+
+	// Amount The transaction amount in milliunits format.  Split transaction amounts cannot be changed and if a different amount is supplied it will be ignored.
+	Amount int `json:"amount,omitempty"`
+
+	// Approved Whether or not the transaction is approved.  If not supplied, transaction will be unapproved by default.
+	Approved bool `json:"approved,omitempty"`
+
+	// CategoryId The category for the transaction.  To configure a split transaction, you can specify null for `category_id` and provide a `subtransactions` array as part of the transaction object.  If an existing transaction is a split, the `category_id` cannot be changed.  Credit Card Payment categories are not permitted and will be ignored if supplied.
+	CategoryId string `json:"category_id,omitempty"`
+
+	// Cleared The cleared status of the transaction
+	Cleared *TransactionClearedStatus `json:"cleared,omitempty"`
+
+	// Date The transaction date in ISO format (e.g. 2016-12-01).  Future dates (scheduled transactions) are not permitted.  Split transaction dates cannot be changed and if a different date is supplied it will be ignored.
+	Date string `json:"date,omitempty"`
+
+	// FlagColor The transaction flag
+	FlagColor *TransactionFlagColor `json:"flag_color"`
+	Memo      string                `json:"memo,omitempty"`
+
+	// PayeeId The payee for the transaction.  To create a transfer between two accounts, use the account transfer payee pointing to the target account.  Account transfer payees are specified as `transfer_payee_id` on the account resource.
+	PayeeId string `json:"payee_id,omitempty"`
+
+	// PayeeName The payee name.  If a `payee_name` value is provided and `payee_id` has a null value, the `payee_name` value will be used to resolve the payee by either (1) a matching payee rename rule (only if `import_id` is also specified) or (2) a payee with the same name or (3) creation of a new payee.
+	PayeeName string `json:"payee_name,omitempty"`
+}
+
+// SaveTransactionsResponse defines model for SaveTransactionsResponse.
+type SaveTransactionsResponse struct {
+	Data struct {
+		// DuplicateImportIds If multiple transactions were specified, a list of import_ids that were not created because of an existing `import_id` found on the same account
+		DuplicateImportIds *[]string `json:"duplicate_import_ids,omitempty"`
+
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int                `json:"server_knowledge"`
+		Transaction     *TransactionDetail `json:"transaction,omitempty"`
+
+		// TransactionIds The transaction ids that were saved
+		TransactionIds []string `json:"transaction_ids"`
+
+		// Transactions If multiple transactions were specified, the transactions that were saved
+		Transactions *[]TransactionDetail `json:"transactions,omitempty"`
+	} `json:"data"`
+}
+
+// ScheduledSubTransaction defines model for ScheduledSubTransaction.
+type ScheduledSubTransaction struct {
+	// Amount The scheduled subtransaction amount in milliunits format
+	Amount       int     `json:"amount"`
+	CategoryId   *string `json:"category_id"`
+	CategoryName *string `json:"category_name"`
+
+	// Deleted Whether or not the scheduled subtransaction has been deleted. Deleted scheduled subtransactions will only be included in delta requests.
+	Deleted                bool    `json:"deleted"`
+	Id                     string  `json:"id"`
+	Memo                   *string `json:"memo"`
+	PayeeId                *string `json:"payee_id"`
+	PayeeName              *string `json:"payee_name"`
+	ScheduledTransactionId string  `json:"scheduled_transaction_id"`
+
+	// TransferAccountId If a transfer, the account_id which the scheduled subtransaction transfers to
+	TransferAccountId *string `json:"transfer_account_id"`
+}
+
+// ScheduledTransactionDetail defines model for ScheduledTransactionDetail.
+type ScheduledTransactionDetail struct {
+	// Embedded struct due to allOf(#/components/schemas/ScheduledTransactionSummary)
+	ScheduledTransactionSummary `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	AccountName string `json:"account_name"`
+
+	// CategoryName The name of the category.  If a split scheduled transaction, this will be 'Split'.
+	CategoryName string `json:"category_name"`
+	PayeeName    string `json:"payee_name"`
+
+	// Subtransactions If a split scheduled transaction, the subtransactions.
+	Subtransactions []ScheduledSubTransaction `json:"subtransactions"`
+}
+
+// ScheduledTransactionFrequency The scheduled transaction frequency
+type ScheduledTransactionFrequency = string
+
+// ScheduledTransactionResponse defines model for ScheduledTransactionResponse.
+type ScheduledTransactionResponse struct {
+	Data struct {
+		ScheduledTransaction ScheduledTransactionDetail `json:"scheduled_transaction"`
+	} `json:"data"`
+}
+
+// ScheduledTransactionSummary defines model for ScheduledTransactionSummary.
+type ScheduledTransactionSummary struct {
+	AccountId string `json:"account_id"`
+
+	// Amount The scheduled transaction amount in milliunits format
+	Amount     int     `json:"amount"`
+	CategoryId *string `json:"category_id"`
+
+	// DateFirst The first date for which the Scheduled Transaction was scheduled.
+	DateFirst string `json:"date_first"`
+
+	// DateNext The next date for which the Scheduled Transaction is scheduled.
+	DateNext string `json:"date_next"`
+
+	// Deleted Whether or not the scheduled transaction has been deleted.  Deleted scheduled transactions will only be included in delta requests.
+	Deleted bool `json:"deleted"`
+
+	// FlagColor The transaction flag
+	FlagColor *TransactionFlagColor `json:"flag_color"`
+
+	// FlagName The customized name of a transaction flag
+	FlagName *TransactionFlagName `json:"flag_name"`
+
+	// Frequency The scheduled transaction frequency
+	Frequency ScheduledTransactionFrequency `json:"frequency"`
+	Id        string                        `json:"id"`
+	Memo      *string                       `json:"memo"`
+	PayeeId   *string                       `json:"payee_id"`
+
+	// TransferAccountId If a transfer, the account_id which the scheduled transaction transfers to
+	TransferAccountId *string `json:"transfer_account_id"`
+}
+
+// ScheduledTransactionsResponse defines model for ScheduledTransactionsResponse.
+type ScheduledTransactionsResponse struct {
+	Data struct {
+		ScheduledTransactions []ScheduledTransactionDetail `json:"scheduled_transactions"`
+
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int `json:"server_knowledge"`
+	} `json:"data"`
+}
+
+// SubTransaction defines model for SubTransaction.
+type SubTransaction struct {
+	// Amount The subtransaction amount in milliunits format
+	Amount       int     `json:"amount"`
+	CategoryId   *string `json:"category_id"`
+	CategoryName *string `json:"category_name"`
+
+	// Deleted Whether or not the subtransaction has been deleted.  Deleted subtransactions will only be included in delta requests.
+	Deleted       bool    `json:"deleted"`
+	Id            string  `json:"id"`
+	Memo          *string `json:"memo"`
+	PayeeId       *string `json:"payee_id"`
+	PayeeName     *string `json:"payee_name"`
+	TransactionId string  `json:"transaction_id"`
+
+	// TransferAccountId If a transfer, the account_id which the subtransaction transfers to
+	TransferAccountId *string `json:"transfer_account_id"`
+
+	// TransferTransactionId If a transfer, the id of transaction on the other side of the transfer
+	TransferTransactionId *string `json:"transfer_transaction_id"`
+}
+
+// TransactionClearedStatus The cleared status of the transaction
+type TransactionClearedStatus = string
+
+// TransactionDetail defines model for TransactionDetail.
+type TransactionDetail struct {
+	// Embedded struct due to allOf(#/components/schemas/TransactionSummary)
+	TransactionSummary `yaml:",inline"`
+	// Embedded fields due to inline allOf schema
+	AccountName string `json:"account_name"`
+
+	// CategoryName The name of the category.  If a split transaction, this will be 'Split'.
+	CategoryName string `json:"category_name"`
+	PayeeName    string `json:"payee_name"`
+
+	// Subtransactions If a split transaction, the subtransactions.
+	Subtransactions []SubTransaction `json:"subtransactions"`
+}
+
+// TransactionFlagColor The transaction flag
+type TransactionFlagColor = string
+
+// TransactionFlagName The customized name of a transaction flag
+type TransactionFlagName = string
+
+// TransactionResponse defines model for TransactionResponse.
+type TransactionResponse struct {
+	Data struct {
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int               `json:"server_knowledge"`
+		Transaction     TransactionDetail `json:"transaction"`
+	} `json:"data"`
+}
+
+// TransactionSummary defines model for TransactionSummary.
+type TransactionSummary struct {
+	AccountId string `json:"account_id"`
+
+	// Amount The transaction amount in milliunits format
+	Amount int `json:"amount"`
+
+	// Approved Whether or not the transaction is approved
+	Approved   bool    `json:"approved"`
+	CategoryId *string `json:"category_id"`
+
+	// Cleared The cleared status of the transaction
+	Cleared TransactionClearedStatus `json:"cleared"`
+
+	// Date The transaction date in ISO format (e.g. 2016-12-01)
+	Date string `json:"date"`
+
+	// Deleted Whether or not the transaction has been deleted.  Deleted transactions will only be included in delta requests.
+	Deleted bool `json:"deleted"`
+
+	// FlagColor The transaction flag
+	FlagColor *TransactionFlagColor `json:"flag_color"`
+
+	// FlagName The customized name of a transaction flag
+	FlagName *TransactionFlagName `json:"flag_name"`
+	Id       string               `json:"id"`
+
+	// ImportId If the transaction was imported, this field is a unique (by account) import identifier.  If this transaction was imported through File Based Import or Direct Import and not through the API, the import_id will have the format 'YNAB:[milliunit_amount]:[iso_date]:[occurrence]'.  For example, a transaction dated 2015-12-30 in the amount of -$294.23 USD would have an import_id of 'YNAB:-294230:2015-12-30:1'.  If a second transaction on the same account was imported and had the same date and same amount, its import_id would be 'YNAB:-294230:2015-12-30:2'.
+	ImportId *string `json:"import_id"`
+
+	// ImportPayeeName If the transaction was imported, the payee name that was used when importing and before applying any payee rename rules
+	ImportPayeeName *string `json:"import_payee_name"`
+
+	// ImportPayeeNameOriginal If the transaction was imported, the original payee name as it appeared on the statement
+	ImportPayeeNameOriginal *string `json:"import_payee_name_original"`
+
+	// MatchedTransactionId If transaction is matched, the id of the matched transaction
+	MatchedTransactionId *string `json:"matched_transaction_id"`
+	Memo                 *string `json:"memo"`
+	PayeeId              *string `json:"payee_id"`
+
+	// TransferAccountId If a transfer transaction, the account to which it transfers
+	TransferAccountId *string `json:"transfer_account_id"`
+
+	// TransferTransactionId If a transfer transaction, the id of transaction on the other side of the transfer
+	TransferTransactionId *string `json:"transfer_transaction_id"`
+}
+
+// TransactionsResponse defines model for TransactionsResponse.
+type TransactionsResponse struct {
+	Data struct {
+		// ServerKnowledge The knowledge of the server
+		ServerKnowledge int                 `json:"server_knowledge"`
+		Transactions    []TransactionDetail `json:"transactions"`
+	} `json:"data"`
+}
+
+// GetBudgetsParams defines parameters for GetBudgets.
+type GetBudgetsParams struct {
+	// IncludeAccounts Whether to include the list of budget accounts
+	IncludeAccounts *bool `form:"include_accounts,omitempty" json:"include_accounts,omitempty"`
+}
+
+// GetAccountsParams defines parameters for GetAccounts.
+type GetAccountsParams struct {
+	// LastKnowledgeOfServer The starting server knowledge.  If provided, only entities that have changed since `last_knowledge_of_server` will be included.
+	LastKnowledgeOfServer *int `form:"last_knowledge_of_server,omitempty" json:"last_knowledge_of_server,omitempty"`
+}
+
+// GetTransactionsByAccountParams defines parameters for GetTransactionsByAccount.
+type GetTransactionsByAccountParams struct {
+	// SinceDate If specified, only transactions on or after this date will be included.  The date should be ISO formatted (e.g. 2016-12-30).
+	SinceDate *string `form:"since_date,omitempty" json:"since_date,omitempty"`
+
+	// Type If specified, only transactions of the specified type will be included. "uncategorized" and "unapproved" are currently supported.
+	Type *GetTransactionsByAccountParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// LastKnowledgeOfServer The starting server knowledge.  If provided, only entities that have changed since `last_knowledge_of_server` will be included.
+	LastKnowledgeOfServer *int `form:"last_knowledge_of_server,omitempty" json:"last_knowledge_of_server,omitempty"`
+}
+
+// GetTransactionsByAccountParamsType defines parameters for GetTransactionsByAccount.
+type GetTransactionsByAccountParamsType string
+
+// GetCategoriesParams defines parameters for GetCategories.
+type GetCategoriesParams struct {
+	// LastKnowledgeOfServer The starting server knowledge.  If provided, only entities that have changed since `last_knowledge_of_server` will be included.
+	LastKnowledgeOfServer *int `form:"last_knowledge_of_server,omitempty" json:"last_knowledge_of_server,omitempty"`
+}
+
+// GetPayeesParams defines parameters for GetPayees.
+type GetPayeesParams struct {
+	// LastKnowledgeOfServer The starting server knowledge.  If provided, only entities that have changed since `last_knowledge_of_server` will be included.
+	LastKnowledgeOfServer *int `form:"last_knowledge_of_server,omitempty" json:"last_knowledge_of_server,omitempty"`
+}
+
+// GetScheduledTransactionsParams defines parameters for GetScheduledTransactions.
+type GetScheduledTransactionsParams struct {
+	// LastKnowledgeOfServer The starting server knowledge.  If provided, only entities that have changed since `last_knowledge_of_server` will be included.
+	LastKnowledgeOfServer *int `form:"last_knowledge_of_server,omitempty" json:"last_knowledge_of_server,omitempty"`
+}
+
+// CreateScheduledTransactionJSONRequestBody defines body for CreateScheduledTransaction for application/json ContentType.
+type CreateScheduledTransactionJSONRequestBody = PostScheduledTransactionWrapper
+
+// CreateTransactionJSONRequestBody defines body for CreateTransaction for application/json ContentType.
+type CreateTransactionJSONRequestBody = PostTransactionsWrapper
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetBudgets request
+	GetBudgets(ctx context.Context, params *GetBudgetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAccounts request
+	GetAccounts(ctx context.Context, budgetId string, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactionsByAccount request
+	GetTransactionsByAccount(ctx context.Context, budgetId string, accountId string, params *GetTransactionsByAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCategories request
+	GetCategories(ctx context.Context, budgetId string, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPayees request
+	GetPayees(ctx context.Context, budgetId string, params *GetPayeesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetScheduledTransactions request
+	GetScheduledTransactions(ctx context.Context, budgetId string, params *GetScheduledTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateScheduledTransactionWithBody request with any body
+	CreateScheduledTransactionWithBody(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateScheduledTransaction(ctx context.Context, budgetId string, body CreateScheduledTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateTransactionWithBody request with any body
+	CreateTransactionWithBody(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateTransaction(ctx context.Context, budgetId string, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTransaction request
+	DeleteTransaction(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTransactionById request
+	GetTransactionById(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetBudgets(ctx context.Context, params *GetBudgetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetBudgetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAccounts(ctx context.Context, budgetId string, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAccountsRequest(c.Server, budgetId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactionsByAccount(ctx context.Context, budgetId string, accountId string, params *GetTransactionsByAccountParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionsByAccountRequest(c.Server, budgetId, accountId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCategories(ctx context.Context, budgetId string, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCategoriesRequest(c.Server, budgetId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPayees(ctx context.Context, budgetId string, params *GetPayeesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPayeesRequest(c.Server, budgetId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetScheduledTransactions(ctx context.Context, budgetId string, params *GetScheduledTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetScheduledTransactionsRequest(c.Server, budgetId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateScheduledTransactionWithBody(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduledTransactionRequestWithBody(c.Server, budgetId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateScheduledTransaction(ctx context.Context, budgetId string, body CreateScheduledTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateScheduledTransactionRequest(c.Server, budgetId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransactionWithBody(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionRequestWithBody(c.Server, budgetId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTransaction(ctx context.Context, budgetId string, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTransactionRequest(c.Server, budgetId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTransaction(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTransactionRequest(c.Server, budgetId, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTransactionById(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTransactionByIdRequest(c.Server, budgetId, transactionId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetBudgetsRequest generates requests for GetBudgets
+func NewGetBudgetsRequest(server string, params *GetBudgetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.IncludeAccounts != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "include_accounts", runtime.ParamLocationQuery, *params.IncludeAccounts); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAccountsRequest generates requests for GetAccounts
+func NewGetAccountsRequest(server string, budgetId string, params *GetAccountsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/accounts", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastKnowledgeOfServer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "last_knowledge_of_server", runtime.ParamLocationQuery, *params.LastKnowledgeOfServer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionsByAccountRequest generates requests for GetTransactionsByAccount
+func NewGetTransactionsByAccountRequest(server string, budgetId string, accountId string, params *GetTransactionsByAccountParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "account_id", runtime.ParamLocationPath, accountId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/accounts/%s/transactions", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.SinceDate != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "since_date", runtime.ParamLocationQuery, *params.SinceDate); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.LastKnowledgeOfServer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "last_knowledge_of_server", runtime.ParamLocationQuery, *params.LastKnowledgeOfServer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCategoriesRequest generates requests for GetCategories
+func NewGetCategoriesRequest(server string, budgetId string, params *GetCategoriesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/categories", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastKnowledgeOfServer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "last_knowledge_of_server", runtime.ParamLocationQuery, *params.LastKnowledgeOfServer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetPayeesRequest generates requests for GetPayees
+func NewGetPayeesRequest(server string, budgetId string, params *GetPayeesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/payees", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastKnowledgeOfServer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "last_knowledge_of_server", runtime.ParamLocationQuery, *params.LastKnowledgeOfServer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetScheduledTransactionsRequest generates requests for GetScheduledTransactions
+func NewGetScheduledTransactionsRequest(server string, budgetId string, params *GetScheduledTransactionsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/scheduled_transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.LastKnowledgeOfServer != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "last_knowledge_of_server", runtime.ParamLocationQuery, *params.LastKnowledgeOfServer); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateScheduledTransactionRequest calls the generic CreateScheduledTransaction builder with application/json body
+func NewCreateScheduledTransactionRequest(server string, budgetId string, body CreateScheduledTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateScheduledTransactionRequestWithBody(server, budgetId, "application/json", bodyReader)
+}
+
+// NewCreateScheduledTransactionRequestWithBody generates requests for CreateScheduledTransaction with any type of body
+func NewCreateScheduledTransactionRequestWithBody(server string, budgetId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/scheduled_transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateTransactionRequest calls the generic CreateTransaction builder with application/json body
+func NewCreateTransactionRequest(server string, budgetId string, body CreateTransactionJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTransactionRequestWithBody(server, budgetId, "application/json", bodyReader)
+}
+
+// NewCreateTransactionRequestWithBody generates requests for CreateTransaction with any type of body
+func NewCreateTransactionRequestWithBody(server string, budgetId string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/transactions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTransactionRequest generates requests for DeleteTransaction
+func NewDeleteTransactionRequest(server string, budgetId string, transactionId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transaction_id", runtime.ParamLocationPath, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/transactions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTransactionByIdRequest generates requests for GetTransactionById
+func NewGetTransactionByIdRequest(server string, budgetId string, transactionId string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "budget_id", runtime.ParamLocationPath, budgetId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "transaction_id", runtime.ParamLocationPath, transactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/budgets/%s/transactions/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetBudgetsWithResponse request
+	GetBudgetsWithResponse(ctx context.Context, params *GetBudgetsParams, reqEditors ...RequestEditorFn) (*GetBudgetsResponse, error)
+
+	// GetAccountsWithResponse request
+	GetAccountsWithResponse(ctx context.Context, budgetId string, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsResponse, error)
+
+	// GetTransactionsByAccountWithResponse request
+	GetTransactionsByAccountWithResponse(ctx context.Context, budgetId string, accountId string, params *GetTransactionsByAccountParams, reqEditors ...RequestEditorFn) (*GetTransactionsByAccountResponse, error)
+
+	// GetCategoriesWithResponse request
+	GetCategoriesWithResponse(ctx context.Context, budgetId string, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*GetCategoriesResponse, error)
+
+	// GetPayeesWithResponse request
+	GetPayeesWithResponse(ctx context.Context, budgetId string, params *GetPayeesParams, reqEditors ...RequestEditorFn) (*GetPayeesResponse, error)
+
+	// GetScheduledTransactionsWithResponse request
+	GetScheduledTransactionsWithResponse(ctx context.Context, budgetId string, params *GetScheduledTransactionsParams, reqEditors ...RequestEditorFn) (*GetScheduledTransactionsResponse, error)
+
+	// CreateScheduledTransactionWithBodyWithResponse request with any body
+	CreateScheduledTransactionWithBodyWithResponse(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduledTransactionResponse, error)
+
+	CreateScheduledTransactionWithResponse(ctx context.Context, budgetId string, body CreateScheduledTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduledTransactionResponse, error)
+
+	// CreateTransactionWithBodyWithResponse request with any body
+	CreateTransactionWithBodyWithResponse(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error)
+
+	CreateTransactionWithResponse(ctx context.Context, budgetId string, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error)
+
+	// DeleteTransactionWithResponse request
+	DeleteTransactionWithResponse(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*DeleteTransactionResponse, error)
+
+	// GetTransactionByIdWithResponse request
+	GetTransactionByIdWithResponse(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*GetTransactionByIdResponse, error)
+}
+
+type GetBudgetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *BudgetSummaryResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetBudgetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetBudgetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAccountsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AccountsResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAccountsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAccountsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionsByAccountResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionsResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionsByAccountResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionsByAccountResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCategoriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CategoriesResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCategoriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCategoriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPayeesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PayeesResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPayeesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPayeesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetScheduledTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScheduledTransactionsResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetScheduledTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetScheduledTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateScheduledTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ScheduledTransactionResponse
+	JSON400      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateScheduledTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateScheduledTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *SaveTransactionsResponse
+	JSON400      *ErrorResponse
+	JSON409      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTransactionResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTransactionResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTransactionResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTransactionByIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionResponse
+	JSON404      *ErrorResponse
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTransactionByIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTransactionByIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetBudgetsWithResponse request returning *GetBudgetsResponse
+func (c *ClientWithResponses) GetBudgetsWithResponse(ctx context.Context, params *GetBudgetsParams, reqEditors ...RequestEditorFn) (*GetBudgetsResponse, error) {
+	rsp, err := c.GetBudgets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetBudgetsResponse(rsp)
+}
+
+// GetAccountsWithResponse request returning *GetAccountsResponse
+func (c *ClientWithResponses) GetAccountsWithResponse(ctx context.Context, budgetId string, params *GetAccountsParams, reqEditors ...RequestEditorFn) (*GetAccountsResponse, error) {
+	rsp, err := c.GetAccounts(ctx, budgetId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAccountsResponse(rsp)
+}
+
+// GetTransactionsByAccountWithResponse request returning *GetTransactionsByAccountResponse
+func (c *ClientWithResponses) GetTransactionsByAccountWithResponse(ctx context.Context, budgetId string, accountId string, params *GetTransactionsByAccountParams, reqEditors ...RequestEditorFn) (*GetTransactionsByAccountResponse, error) {
+	rsp, err := c.GetTransactionsByAccount(ctx, budgetId, accountId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionsByAccountResponse(rsp)
+}
+
+// GetCategoriesWithResponse request returning *GetCategoriesResponse
+func (c *ClientWithResponses) GetCategoriesWithResponse(ctx context.Context, budgetId string, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*GetCategoriesResponse, error) {
+	rsp, err := c.GetCategories(ctx, budgetId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCategoriesResponse(rsp)
+}
+
+// GetPayeesWithResponse request returning *GetPayeesResponse
+func (c *ClientWithResponses) GetPayeesWithResponse(ctx context.Context, budgetId string, params *GetPayeesParams, reqEditors ...RequestEditorFn) (*GetPayeesResponse, error) {
+	rsp, err := c.GetPayees(ctx, budgetId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPayeesResponse(rsp)
+}
+
+// GetScheduledTransactionsWithResponse request returning *GetScheduledTransactionsResponse
+func (c *ClientWithResponses) GetScheduledTransactionsWithResponse(ctx context.Context, budgetId string, params *GetScheduledTransactionsParams, reqEditors ...RequestEditorFn) (*GetScheduledTransactionsResponse, error) {
+	rsp, err := c.GetScheduledTransactions(ctx, budgetId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetScheduledTransactionsResponse(rsp)
+}
+
+// CreateScheduledTransactionWithBodyWithResponse request with arbitrary body returning *CreateScheduledTransactionResponse
+func (c *ClientWithResponses) CreateScheduledTransactionWithBodyWithResponse(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateScheduledTransactionResponse, error) {
+	rsp, err := c.CreateScheduledTransactionWithBody(ctx, budgetId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduledTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateScheduledTransactionWithResponse(ctx context.Context, budgetId string, body CreateScheduledTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateScheduledTransactionResponse, error) {
+	rsp, err := c.CreateScheduledTransaction(ctx, budgetId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateScheduledTransactionResponse(rsp)
+}
+
+// CreateTransactionWithBodyWithResponse request with arbitrary body returning *CreateTransactionResponse
+func (c *ClientWithResponses) CreateTransactionWithBodyWithResponse(ctx context.Context, budgetId string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error) {
+	rsp, err := c.CreateTransactionWithBody(ctx, budgetId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateTransactionWithResponse(ctx context.Context, budgetId string, body CreateTransactionJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateTransactionResponse, error) {
+	rsp, err := c.CreateTransaction(ctx, budgetId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateTransactionResponse(rsp)
+}
+
+// DeleteTransactionWithResponse request returning *DeleteTransactionResponse
+func (c *ClientWithResponses) DeleteTransactionWithResponse(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*DeleteTransactionResponse, error) {
+	rsp, err := c.DeleteTransaction(ctx, budgetId, transactionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTransactionResponse(rsp)
+}
+
+// GetTransactionByIdWithResponse request returning *GetTransactionByIdResponse
+func (c *ClientWithResponses) GetTransactionByIdWithResponse(ctx context.Context, budgetId string, transactionId string, reqEditors ...RequestEditorFn) (*GetTransactionByIdResponse, error) {
+	rsp, err := c.GetTransactionById(ctx, budgetId, transactionId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTransactionByIdResponse(rsp)
+}
+
+// ParseGetBudgetsResponse parses an HTTP response from a GetBudgetsWithResponse call
+func ParseGetBudgetsResponse(rsp *http.Response) (*GetBudgetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetBudgetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BudgetSummaryResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAccountsResponse parses an HTTP response from a GetAccountsWithResponse call
+func ParseGetAccountsResponse(rsp *http.Response) (*GetAccountsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAccountsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AccountsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionsByAccountResponse parses an HTTP response from a GetTransactionsByAccountWithResponse call
+func ParseGetTransactionsByAccountResponse(rsp *http.Response) (*GetTransactionsByAccountResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionsByAccountResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCategoriesResponse parses an HTTP response from a GetCategoriesWithResponse call
+func ParseGetCategoriesResponse(rsp *http.Response) (*GetCategoriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCategoriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CategoriesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPayeesResponse parses an HTTP response from a GetPayeesWithResponse call
+func ParseGetPayeesResponse(rsp *http.Response) (*GetPayeesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPayeesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PayeesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetScheduledTransactionsResponse parses an HTTP response from a GetScheduledTransactionsWithResponse call
+func ParseGetScheduledTransactionsResponse(rsp *http.Response) (*GetScheduledTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetScheduledTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduledTransactionsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateScheduledTransactionResponse parses an HTTP response from a CreateScheduledTransactionWithResponse call
+func ParseCreateScheduledTransactionResponse(rsp *http.Response) (*CreateScheduledTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateScheduledTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest ScheduledTransactionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateTransactionResponse parses an HTTP response from a CreateTransactionWithResponse call
+func ParseCreateTransactionResponse(rsp *http.Response) (*CreateTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest SaveTransactionsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTransactionResponse parses an HTTP response from a DeleteTransactionWithResponse call
+func ParseDeleteTransactionResponse(rsp *http.Response) (*DeleteTransactionResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTransactionResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTransactionByIdResponse parses an HTTP response from a GetTransactionByIdWithResponse call
+func ParseGetTransactionByIdResponse(rsp *http.Response) (*GetTransactionByIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTransactionByIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}